@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"path"
 )
 
@@ -19,113 +21,164 @@ func (c *OrganisationApiClient) CreateAccount(data AccountData) (*ClientResponse
 }
 
 // CreateAccountWithContext Creates a new resource given the AccountData with the given context.
+// Non-201 responses are returned as an *APIError, and invalid data as ValidationErrors when ValidateBeforeCreate is set.
 func (c *OrganisationApiClient) CreateAccountWithContext(data AccountData, ctx context.Context) (*ClientResponse, error) {
+	return c.createAccount(ctx, data)
+}
+
+func (c *OrganisationApiClient) createAccount(ctx context.Context, data AccountData) (_ *ClientResponse, err error) {
+	op := operation{name: "CreateAccount", accountID: data.ID}
+	ctx, span := c.startSpan(ctx, op)
+	defer func() { c.endSpan(span, err) }()
+
+	if c.ClientConfig.ValidateBeforeCreate {
+		if err := data.Validate(); err != nil {
+			c.logError(op, err)
+			return nil, err
+		}
+	}
+
 	requestUrl, err := buildAccountsUrl(c)
 
 	if err != nil {
-		logMsg(c.ClientConfig.DebugLog, err.Error())
+		c.logError(op, err)
 		return nil, err
 	}
 
 	jsonValue, err := json.Marshal(dataHolder{Data: data})
 	if err != nil {
-		logMsg(c.ClientConfig.DebugLog, err.Error())
+		c.logError(op, err)
 		return nil, err
 	}
 
-	req, err := createRequest(ctx, http.MethodPost, *requestUrl, bytes.NewBuffer(jsonValue))
+	req, err := c.newRequest(ctx, http.MethodPost, *requestUrl, bytes.NewBuffer(jsonValue))
 	if err != nil {
-		logMsg(c.ClientConfig.DebugLog, err.Error())
+		c.logError(op, err)
 		return nil, err
 	}
 
-	resp, err := c.Do(req)
-
+	resp, err := c.do(req, op)
 	if err != nil {
-		logMsg(c.ClientConfig.DebugLog, err.Error())
 		return nil, err
 	}
 
 	statusCode := resp.StatusCode
-	logMsg(c.ClientConfig.DebugLog, "Received status: ", resp.Status)
 	if statusCode != http.StatusCreated {
-		return &ClientResponse{
-			Data:       nil,
-			StatusCode: resp.StatusCode,
-			Success:    false,
-		}, nil
+		apiErr := newAPIError(c, resp)
+		if statusCode == http.StatusConflict && c.ClientConfig.ResolveCreateConflicts {
+			return c.resolveCreateConflict(ctx, data, apiErr)
+		}
+		return nil, apiErr
 	}
 
 	defer closeBody(resp.Body)
 
-	respData, err := fetchAccountDataFromBody(c, resp)
+	respData, links, err := fetchAccountDataFromBody(c, resp)
 	if err != nil {
-		logMsg(c.ClientConfig.DebugLog, err.Error())
+		c.logError(op, err)
 		return nil, err
 	}
 
 	return &ClientResponse{
 		Data:       respData,
+		Links:      links,
 		StatusCode: resp.StatusCode,
 		Success:    true,
 	}, nil
 }
 
+// resolveCreateConflict Fetches the account that caused a create conflict and compares it with the sent data.
+// An identical account is returned as a pre-existing success, a different one as a *ConflictError.
+func (c *OrganisationApiClient) resolveCreateConflict(ctx context.Context, sent AccountData, conflictErr error) (*ClientResponse, error) {
+	var apiErr *APIError
+	if !errors.As(conflictErr, &apiErr) || sent.ID == "" {
+		return nil, conflictErr
+	}
+
+	c.logger().Info("resolving create conflict", "account_id", sent.ID)
+
+	fetched, err := c.fetchAccount(ctx, sent.ID)
+	if errors.Is(err, ErrNotFound) {
+		// the conflict isn't about the ID, e.g. a duplicated account number
+		return nil, conflictErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := accountMatches(sent, *fetched.Data)
+	if err != nil {
+		return nil, err
+	}
+	if !matches {
+		return nil, &ConflictError{
+			Sent:     &sent,
+			Existing: fetched.Data,
+			Err:      apiErr,
+		}
+	}
+
+	return &ClientResponse{
+		Data:        fetched.Data,
+		Links:       fetched.Links,
+		StatusCode:  apiErr.StatusCode,
+		Success:     true,
+		PreExisting: true,
+	}, nil
+}
+
 // FetchAccount Fetches the account given an id. Uses defaultContext as the context.
 func (c *OrganisationApiClient) FetchAccount(id string) (*ClientResponse, error) {
 	return c.FetchAccountWithContext(id, defaultContext)
 }
 
 // FetchAccountWithContext Fetches the account given an id and context.
+// Non-200 responses are returned as an *APIError.
 func (c *OrganisationApiClient) FetchAccountWithContext(id string, ctx context.Context) (*ClientResponse, error) {
-	requestUrl, err := buildAccountsUrl(c)
+	return c.fetchAccount(ctx, id)
+}
+
+func (c *OrganisationApiClient) fetchAccount(ctx context.Context, id string) (_ *ClientResponse, err error) {
+	op := operation{name: "FetchAccount", accountID: id}
+	ctx, span := c.startSpan(ctx, op)
+	defer func() { c.endSpan(span, err) }()
 
-	logMsg(c.ClientConfig.DebugLog, "Fetching msg", id)
+	requestUrl, err := buildAccountsUrl(c)
 
 	if err != nil {
-		logMsg(c.ClientConfig.DebugLog, err.Error())
+		c.logError(op, err)
 		return nil, err
 	}
 
 	requestUrl.Path = path.Join(requestUrl.Path, id)
 
-	req, err := createRequest(ctx, http.MethodGet, *requestUrl, nil)
-	if err != nil {
-		logMsg(c.ClientConfig.DebugLog, err.Error())
-		return nil, err
-	}
-
-	resp, err := c.Do(req)
+	req, err := c.newRequest(ctx, http.MethodGet, *requestUrl, nil)
 	if err != nil {
-		logMsg(c.ClientConfig.DebugLog, err.Error())
+		c.logError(op, err)
 		return nil, err
 	}
 
+	resp, err := c.do(req, op)
 	if err != nil {
-		logMsg(c.ClientConfig.DebugLog, err.Error())
 		return nil, err
 	}
 
 	statusCode := resp.StatusCode
-	logMsg(c.ClientConfig.DebugLog, "Received status: ", resp.Status)
 	if statusCode != http.StatusOK {
-		return &ClientResponse{
-			Data:       nil,
-			StatusCode: resp.StatusCode,
-			Success:    false,
-		}, nil
+		return nil, newAPIError(c, resp)
 	}
 
 	defer closeBody(resp.Body)
 
-	respData, err := fetchAccountDataFromBody(c, resp)
+	respData, links, err := fetchAccountDataFromBody(c, resp)
 	if err != nil {
-		logMsg(c.ClientConfig.DebugLog, err.Error())
+		c.logError(op, err)
 		return nil, err
 	}
 
 	return &ClientResponse{
 		Data:       respData,
+		Links:      links,
 		StatusCode: resp.StatusCode,
 		Success:    true,
 	}, nil
@@ -137,34 +190,221 @@ func (c *OrganisationApiClient) DeleteAccount(id string, version int64) (*Client
 }
 
 // DeleteAccountWithContext Deletes account with given id, version and context.
+// Non-204 responses are returned as an *APIError.
 func (c *OrganisationApiClient) DeleteAccountWithContext(id string, version int64, ctx context.Context) (*ClientResponse, error) {
+	return c.deleteAccount(ctx, id, version)
+}
+
+func (c *OrganisationApiClient) deleteAccount(ctx context.Context, id string, version int64) (_ *ClientResponse, err error) {
+	op := operation{name: "DeleteAccount", accountID: id}
+	ctx, span := c.startSpan(ctx, op)
+	defer func() { c.endSpan(span, err) }()
+
 	requestUrl, err := buildAccountsUrl(c)
 
 	if err != nil {
-		logMsg(c.ClientConfig.DebugLog, err.Error())
+		c.logError(op, err)
 		return nil, err
 	}
 	requestUrl.Path = path.Join(requestUrl.Path, id)
 	requestUrl.RawQuery = fmt.Sprintf("version=%d", version)
 
-	req, err := createRequest(ctx, http.MethodDelete, *requestUrl, nil)
+	req, err := c.newRequest(ctx, http.MethodDelete, *requestUrl, nil)
 	if err != nil {
-		logMsg(c.ClientConfig.DebugLog, err.Error())
+		c.logError(op, err)
 		return nil, err
 	}
 
-	resp, err := c.Do(req)
+	resp, err := c.do(req, op)
 	if err != nil {
 		return nil, err
 	}
 
-	logMsg(c.ClientConfig.DebugLog, "Received status: ", resp.Status)
-	
+	if resp.StatusCode != http.StatusNoContent {
+		return nil, newAPIError(c, resp)
+	}
+
 	defer closeBody(resp.Body)
 
 	return &ClientResponse{
 		Data:       nil,
 		StatusCode: resp.StatusCode,
-		Success:    resp.StatusCode == http.StatusNoContent,
+		Success:    true,
+	}, nil
+}
+
+// ListAccounts Lists the accounts matching the given options. Uses defaultContext as the context.
+func (c *OrganisationApiClient) ListAccounts(opts ListOptions) (*ListResponse, error) {
+	return c.ListAccountsWithContext(opts, defaultContext)
+}
+
+// ListAccountsWithContext Lists the accounts matching the given options with the given context.
+// Non-200 responses are returned as an *APIError.
+func (c *OrganisationApiClient) ListAccountsWithContext(opts ListOptions, ctx context.Context) (*ListResponse, error) {
+	return c.listAccounts(ctx, opts)
+}
+
+func (c *OrganisationApiClient) listAccounts(ctx context.Context, opts ListOptions) (*ListResponse, error) {
+	op := operation{name: "ListAccounts"}
+
+	requestUrl, err := buildAccountsUrl(c)
+
+	if err != nil {
+		c.logError(op, err)
+		return nil, err
+	}
+	requestUrl.RawQuery = encodeListOptions(opts)
+
+	return c.listAccountsPage(ctx, *requestUrl)
+}
+
+func (c *OrganisationApiClient) listAccountsPage(ctx context.Context, requestUrl url.URL) (_ *ListResponse, err error) {
+	op := operation{name: "ListAccounts"}
+	ctx, span := c.startSpan(ctx, op)
+	defer func() { c.endSpan(span, err) }()
+
+	req, err := c.newRequest(ctx, http.MethodGet, requestUrl, nil)
+	if err != nil {
+		c.logError(op, err)
+		return nil, err
+	}
+
+	resp, err := c.do(req, op)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(c, resp)
+	}
+
+	defer closeBody(resp.Body)
+
+	list, err := fetchAccountListFromBody(c, resp)
+	if err != nil {
+		c.logError(op, err)
+		return nil, err
+	}
+
+	return &ListResponse{
+		Data:       list.Data,
+		Links:      list.Links,
+		StatusCode: resp.StatusCode,
+		Success:    true,
+	}, nil
+}
+
+// AccountMutation Function applied to a freshly fetched account by UpdateAccountWithRetry.
+type AccountMutation func(data *AccountData) error
+
+// UpdateAccount Patches the account with the given AccountData. Uses defaultContext as the context.
+func (c *OrganisationApiClient) UpdateAccount(data AccountData) (*ClientResponse, error) {
+	return c.UpdateAccountWithContext(data, defaultContext)
+}
+
+// UpdateAccountWithContext Patches the account with the given AccountData and context.
+// The data must carry the current version of the account, a stale version results in an *APIError matching ErrConflict.
+func (c *OrganisationApiClient) UpdateAccountWithContext(data AccountData, ctx context.Context) (*ClientResponse, error) {
+	return c.updateAccount(ctx, data)
+}
+
+func (c *OrganisationApiClient) updateAccount(ctx context.Context, data AccountData) (_ *ClientResponse, err error) {
+	op := operation{name: "UpdateAccount", accountID: data.ID}
+	ctx, span := c.startSpan(ctx, op)
+	defer func() { c.endSpan(span, err) }()
+
+	if data.Version == nil {
+		return nil, ErrMissingVersion
+	}
+
+	requestUrl, err := buildAccountsUrl(c)
+
+	if err != nil {
+		c.logError(op, err)
+		return nil, err
+	}
+	requestUrl.Path = path.Join(requestUrl.Path, data.ID)
+
+	jsonValue, err := json.Marshal(dataHolder{Data: data})
+	if err != nil {
+		c.logError(op, err)
+		return nil, err
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPatch, *requestUrl, bytes.NewBuffer(jsonValue))
+	if err != nil {
+		c.logError(op, err)
+		return nil, err
+	}
+
+	resp, err := c.do(req, op)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(c, resp)
+	}
+
+	defer closeBody(resp.Body)
+
+	respData, links, err := fetchAccountDataFromBody(c, resp)
+	if err != nil {
+		c.logError(op, err)
+		return nil, err
+	}
+
+	return &ClientResponse{
+		Data:       respData,
+		Links:      links,
+		StatusCode: resp.StatusCode,
+		Success:    true,
 	}, nil
 }
+
+// UpdateAccountWithRetry Fetches the account, applies the mutation and patches it, retrying on version conflicts.
+// Uses defaultContext as the context.
+func (c *OrganisationApiClient) UpdateAccountWithRetry(id string, mutate AccountMutation, maxAttempts int) (*ClientResponse, error) {
+	return c.UpdateAccountWithRetryWithContext(id, mutate, maxAttempts, defaultContext)
+}
+
+// UpdateAccountWithRetryWithContext Fetches the account, applies the mutation and patches it with the given context.
+// When the update fails with a version conflict the whole cycle is repeated, up to maxAttempts times. Values lower
+// than 1 make a single attempt.
+func (c *OrganisationApiClient) UpdateAccountWithRetryWithContext(id string, mutate AccountMutation, maxAttempts int, ctx context.Context) (*ClientResponse, error) {
+	return c.updateAccountWithRetry(ctx, id, mutate, maxAttempts)
+}
+
+func (c *OrganisationApiClient) updateAccountWithRetry(ctx context.Context, id string, mutate AccountMutation, maxAttempts int) (*ClientResponse, error) {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		var fetched *ClientResponse
+		fetched, err = c.fetchAccount(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		data := *fetched.Data
+		if err = mutate(&data); err != nil {
+			return nil, err
+		}
+		data.Version = fetched.Data.Version
+
+		var updated *ClientResponse
+		updated, err = c.updateAccount(ctx, data)
+		if err == nil {
+			return updated, nil
+		}
+		if !errors.Is(err, ErrConflict) {
+			return nil, err
+		}
+
+		c.logger().Warn("version conflict", "account_id", id, "attempt", attempt, "max_attempts", maxAttempts)
+	}
+
+	return nil, err
+}
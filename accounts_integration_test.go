@@ -4,21 +4,22 @@
 package organisation_api
 
 import (
+	"os"
 	"testing"
+
+	"github.com/CG-SS/organisation-api/fakeapi"
 )
 
-var c = "GB"
-var accType = "Personal"
 var mockAccountData = AccountData{
 	Attributes: &AccountAttributes{
-		AccountClassification: &accType,
+		AccountClassification: ClassificationPersonal.Ptr(),
 		AccountNumber:         "10000004",
 		BankID:                "400302",
-		BankIDCode:            "GBDSC",
-		BaseCurrency:          "GBP",
+		BankIDCode:            BankIDCodeGBDSC,
+		BaseCurrency:          CurrencyGBP,
 		Bic:                   "NWBKGB42",
-		Country:               &c,
-		Iban:                  "GB28NWBK40030212764204",
+		Country:               CountryGB.Ptr(),
+		Iban:                  "GB71NWBK40030212764204",
 		Name:                  []string{"Kelvin", "Klein"},
 	},
 	ID:             "123e4567-e89b-12d3-a456-426614174129",
@@ -29,6 +30,20 @@ var mockAccountData = AccountData{
 func Test_Integration_OrganisationApiClient(t *testing.T) {
 	client := DefaultClient
 
+	// FAKE_API runs the suite offline against the in-memory fake instead of the docker-compose stack
+	if os.Getenv("FAKE_API") != "" {
+		server := fakeapi.NewServer()
+		defer server.Close()
+
+		client = &OrganisationApiClient{
+			Client: server.Client(),
+			ClientConfig: &ClientConfig{
+				RootUrl:     server.RootURL(),
+				RetryPolicy: DefaultRetryPolicy,
+			},
+		}
+	}
+
 	testAccountCreation(t, client)
 	testAccountFetching(t, client)
 	testAccountDeletion(t, client)
@@ -46,13 +61,12 @@ func testAccountCreation(t *testing.T, client *OrganisationApiClient) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			r, err := client.CreateAccount(tc.payload)
-			if err != nil {
-				t.Fatal("Got client API error! Error:", err)
-			}
-
-			if r.Success == tc.shouldFail || !r.Success == !tc.shouldFail {
+			if (err != nil) != tc.shouldFail {
 				t.Fatal("Fail condition didn't match! Got", err, "should've been", tc.shouldFail)
 			}
+			if err == nil && !r.Success {
+				t.Fatal("Got unsuccessful response! Status code", r.StatusCode)
+			}
 		})
 	}
 }
@@ -70,12 +84,12 @@ func testAccountFetching(t *testing.T, client *OrganisationApiClient) {
 		t.Run(tc.name, func(t *testing.T) {
 			r, err := client.FetchAccount(tc.uuid)
 
-			if err != nil {
-				t.Fatal("Got client API error! Error:", err)
-			}
-			if r.Success == tc.shouldFail || !r.Success == !tc.shouldFail {
+			if (err != nil) != tc.shouldFail {
 				t.Fatal("Fail condition didn't match! Got", err, "should've been", tc.shouldFail)
 			}
+			if err == nil && !r.Success {
+				t.Fatal("Got unsuccessful response! Status code", r.StatusCode)
+			}
 		})
 	}
 }
@@ -93,12 +107,12 @@ func testAccountDeletion(t *testing.T, client *OrganisationApiClient) {
 		t.Run(tc.name, func(t *testing.T) {
 			r, err := client.DeleteAccount(tc.uuid, 0)
 
-			if err != nil {
-				t.Fatal("Got client API error! Error:", err)
-			}
-			if r.Success == tc.shouldFail || !r.Success == !tc.shouldFail {
+			if (err != nil) != tc.shouldFail {
 				t.Fatal("Fail condition didn't match! Got", err, "should've been", tc.shouldFail)
 			}
+			if err == nil && !r.Success {
+				t.Fatal("Got unsuccessful response! Status code", r.StatusCode)
+			}
 		})
 	}
 }
@@ -6,27 +6,28 @@ package organisation_api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/CG-SS/organisation-api/fakeapi"
 )
 
-var c = "GB"
-var accType = "Personal"
 var version int64 = 0
 var mockAccountData = AccountData{
 	Attributes: &AccountAttributes{
-		AccountClassification: &accType,
+		AccountClassification: ClassificationPersonal.Ptr(),
 		AccountNumber:         "10000004",
 		BankID:                "400302",
-		BankIDCode:            "GBDSC",
-		BaseCurrency:          "GBP",
+		BankIDCode:            BankIDCodeGBDSC,
+		BaseCurrency:          CurrencyGBP,
 		Bic:                   "NWBKGB42",
-		Country:               &c,
-		Iban:                  "GB28NWBK40030212764204",
+		Country:               CountryGB.Ptr(),
+		Iban:                  "GB71NWBK40030212764204",
 		Name:                  []string{"Kelvin", "Klein"},
 	},
 	ID:             "123e4567-e89b-12d3-a456-426614174129",
@@ -242,3 +243,306 @@ func TestOrganisationApiClient_FetchAccountWithContext(t *testing.T) {
 		t.Fatal("Should've failed!")
 	}
 }
+
+func TestOrganisationApiClient_ListAccounts(t *testing.T) {
+	c := &OrganisationApiClient{
+		Client: &http.Client{},
+		ClientConfig: &ClientConfig{
+			RootUrl:        defaultRootUrl,
+			DebugLog:       nil,
+			IsDebugEnabled: false,
+		},
+	}
+
+	c.Client.Transport = roundTripAux(
+		func(r *http.Request) (*http.Response, error) {
+			if r.URL.Path != "/v1/organisation/accounts" {
+				t.Fatal("Wrong request path! Got", r.URL.Path)
+			}
+			if r.Method != http.MethodGet {
+				t.Fatal("Wrong method! Got", r.Method)
+			}
+
+			q := r.URL.Query()
+			if q.Get("page[number]") != "2" || q.Get("page[size]") != "50" {
+				t.Fatal("Wrong pagination! Got", r.URL.RawQuery)
+			}
+			if q.Get("filter[country]") != "GB,FR" || q.Get("filter[bank_id]") != "400302" {
+				t.Fatal("Wrong filters! Got", r.URL.RawQuery)
+			}
+			if _, ok := q["filter[iban]"]; ok {
+				t.Fatal("Empty filter was sent! Got", r.URL.RawQuery)
+			}
+
+			j, err := json.Marshal(accountListHolder{Data: []AccountData{mockAccountData}})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(string(j))),
+			}, nil
+		},
+	)
+
+	listResponse, err := c.ListAccounts(ListOptions{
+		PageNumber: 2,
+		PageSize:   50,
+		Filter: AccountFilter{
+			BankID:  []string{"400302"},
+			Country: []string{"GB", "FR"},
+		},
+	})
+	if err != nil {
+		t.Fatal("Got client error", err)
+	}
+	if len(listResponse.Data) != 1 || listResponse.Data[0].ID != mockAccountData.ID {
+		t.Fatal("Wrong accounts listed! Got", listResponse.Data)
+	}
+}
+
+func TestOrganisationApiClient_UpdateAccount(t *testing.T) {
+	c := &OrganisationApiClient{
+		Client: &http.Client{},
+		ClientConfig: &ClientConfig{
+			RootUrl:        defaultRootUrl,
+			DebugLog:       nil,
+			IsDebugEnabled: false,
+		},
+	}
+
+	c.Client.Transport = roundTripAux(
+		func(r *http.Request) (*http.Response, error) {
+			if r.URL.Path != "/v1/organisation/accounts/123e4567-e89b-12d3-a456-426614174129" {
+				t.Fatal("Wrong request path! Got", r.URL.Path)
+			}
+			if r.Method != http.MethodPatch {
+				t.Fatal("Wrong method! Got", r.Method)
+			}
+
+			sent := dataHolder{}
+			if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+				t.Fatal(err)
+			}
+			if sent.Data.Version == nil || *sent.Data.Version != version {
+				t.Fatal("Version wasn't sent! Got", sent.Data.Version)
+			}
+
+			j, err := json.Marshal(sent)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(string(j))),
+			}, nil
+		},
+	)
+
+	clientResponse, err := c.UpdateAccount(mockAccountData)
+	if err != nil {
+		t.Fatal("Got client error", err)
+	}
+	if !clientResponse.Success {
+		t.Fatal("Failed to update account! Got status code", clientResponse.StatusCode)
+	}
+
+	_, err = c.UpdateAccount(AccountData{ID: mockAccountData.ID})
+	if err != ErrMissingVersion {
+		t.Fatal("Expected missing version error, got", err)
+	}
+}
+
+func TestOrganisationApiClient_UpdateAccountWithRetry(t *testing.T) {
+	c := &OrganisationApiClient{
+		Client: &http.Client{},
+		ClientConfig: &ClientConfig{
+			RootUrl:        defaultRootUrl,
+			DebugLog:       nil,
+			IsDebugEnabled: false,
+		},
+	}
+
+	var serverVersion int64 = 0
+	fetches, patches := 0, 0
+	c.Client.Transport = roundTripAux(
+		func(r *http.Request) (*http.Response, error) {
+			switch r.Method {
+			case http.MethodGet:
+				fetches++
+				// a concurrent writer bumps the version between the first fetch and patch
+				if fetches == 1 {
+					defer func() { serverVersion++ }()
+				}
+
+				current := mockAccountData
+				v := serverVersion
+				current.Version = &v
+				j, err := json.Marshal(dataHolder{Data: current})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader(string(j))),
+				}, nil
+			case http.MethodPatch:
+				patches++
+				sent := dataHolder{}
+				if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+					t.Fatal(err)
+				}
+				if *sent.Data.Version != serverVersion {
+					return &http.Response{
+						StatusCode: http.StatusConflict,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"error_message":"invalid version"}`)),
+					}, nil
+				}
+
+				j, err := json.Marshal(sent)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader(string(j))),
+				}, nil
+			}
+
+			t.Fatal("Unexpected method", r.Method)
+			return nil, nil
+		},
+	)
+
+	clientResponse, err := c.UpdateAccountWithRetry(mockAccountData.ID, func(data *AccountData) error {
+		attributes := *data.Attributes
+		attributes.Name = []string{"Calvin", "Klein"}
+		data.Attributes = &attributes
+		return nil
+	}, 3)
+	if err != nil {
+		t.Fatal("Got client error", err)
+	}
+	if clientResponse.Data.Attributes.Name[0] != "Calvin" {
+		t.Fatal("Mutation wasn't applied! Got", clientResponse.Data.Attributes.Name)
+	}
+	if fetches != 2 || patches != 2 {
+		t.Fatal("Expected 2 fetches and 2 patches, got", fetches, "and", patches)
+	}
+
+	_, err = c.UpdateAccountWithRetry(mockAccountData.ID, func(data *AccountData) error {
+		serverVersion++
+		return nil
+	}, 2)
+	if !errors.Is(err, ErrConflict) {
+		t.Fatal("Expected conflict after exhausting attempts, got", err)
+	}
+}
+
+func TestOrganisationApiClient_UpdateAccountWithRetryWithoutAttempts(t *testing.T) {
+	s := fakeapi.NewServer()
+	defer s.Close()
+
+	c, err := NewClient(WithBaseURL(s.RootURL().String()), WithHTTPClient(s.Client()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.CreateAccount(mockAccountData); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, maxAttempts := range []int{0, -1} {
+		resp, err := c.UpdateAccountWithRetry(mockAccountData.ID, func(data *AccountData) error {
+			data.Attributes.SecondaryIdentification = "A1B2C3D4"
+			return nil
+		}, maxAttempts)
+		if err != nil || resp == nil || resp.Data == nil {
+			t.Fatal("Expected a single attempt for", maxAttempts, "max attempts, got", resp, err)
+		}
+	}
+}
+
+func TestOrganisationApiClient_CreateAccountResolvesConflict(t *testing.T) {
+	differentName := *mockAccountData.Attributes
+	differentName.Name = []string{"Calvin", "Klein"}
+	var existingVersion int64 = 3
+
+	testCases := []struct {
+		name        string
+		existing    AccountData
+		resolve     bool
+		preExisting bool
+	}{
+		{"Returns identical account as pre-existing", mockAccountData, true, true},
+		{"Returns conflict for different account", AccountData{
+			Attributes:     &differentName,
+			ID:             mockAccountData.ID,
+			OrganisationID: mockAccountData.OrganisationID,
+			Type:           mockAccountData.Type,
+			Version:        &existingVersion,
+		}, true, false},
+		{"Returns conflict when resolution is disabled", mockAccountData, false, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &OrganisationApiClient{
+				Client: &http.Client{},
+				ClientConfig: &ClientConfig{
+					RootUrl:                defaultRootUrl,
+					DebugLog:               nil,
+					IsDebugEnabled:         false,
+					ResolveCreateConflicts: tc.resolve,
+				},
+			}
+
+			c.Client.Transport = roundTripAux(
+				func(r *http.Request) (*http.Response, error) {
+					if r.Method == http.MethodPost {
+						return &http.Response{
+							StatusCode: http.StatusConflict,
+							Body:       ioutil.NopCloser(strings.NewReader(`{"error_message":"Account cannot be created as it violates a duplicate constraint"}`)),
+						}, nil
+					}
+
+					j, err := json.Marshal(dataHolder{Data: tc.existing})
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(strings.NewReader(string(j))),
+					}, nil
+				},
+			)
+
+			clientResponse, err := c.CreateAccount(mockAccountData)
+			if tc.preExisting {
+				if err != nil {
+					t.Fatal("Got client error", err)
+				}
+				if !clientResponse.Success || !clientResponse.PreExisting {
+					t.Fatal("Expected pre-existing success, got", clientResponse)
+				}
+				return
+			}
+
+			if !errors.Is(err, ErrConflict) {
+				t.Fatal("Expected conflict error, got", err)
+			}
+
+			var conflictErr *ConflictError
+			if errors.As(err, &conflictErr) != tc.resolve {
+				t.Fatal("Unexpected conflict error type, got", err)
+			}
+			if tc.resolve && conflictErr.Existing.Attributes.Name[0] != "Calvin" {
+				t.Fatal("Conflict error doesn't carry the existing account! Got", conflictErr.Existing)
+			}
+		})
+	}
+}
@@ -0,0 +1,421 @@
+// Package accountsmock Programmable implementation of organisation_api.AccountsAPI and
+// organisation_api.AccountsService, for unit testing consumers of the client without HTTP.
+//
+// Calls are matched against the expectations in the order they were registered. The context variants of the
+// operations are recorded and matched under the name of the operation without the suffix, e.g. both FetchAccount and
+// FetchAccountWithContext calls match an expectation on FetchAccount, with the context available in Call.Ctx:
+//
+//	m := accountsmock.New()
+//	m.On(accountsmock.FetchAccount, id).Return(&organisation_api.ClientResponse{Data: &account}, nil)
+//	m.On(accountsmock.DeleteAccount, accountsmock.Any, int64(0)).ReturnError(organisation_api.ErrNotFound)
+//	...
+//	m.AssertExpectations(t)
+//
+// The AccountsService methods are recorded under their own names, e.g. Get:
+//
+//	m.On(accountsmock.Get, id).Return(&account, nil)
+//	m.On(accountsmock.Iterate).Return([]organisation_api.AccountData{account}, nil)
+package accountsmock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	organisation_api "github.com/CG-SS/organisation-api"
+)
+
+// Operation names, as recorded and matched by the mock.
+const (
+	CreateAccount          = "CreateAccount"
+	FetchAccount           = "FetchAccount"
+	DeleteAccount          = "DeleteAccount"
+	ListAccounts           = "ListAccounts"
+	UpdateAccount          = "UpdateAccount"
+	UpdateAccountWithRetry = "UpdateAccountWithRetry"
+	IterateAccounts        = "IterateAccounts"
+
+	Create           = "Create"
+	Get              = "Get"
+	Delete           = "Delete"
+	Update           = "Update"
+	UpdateWithRetry  = "UpdateWithRetry"
+	List             = "List"
+	Iterate          = "Iterate"
+	BulkCreate       = "BulkCreate"
+	BulkCreateStream = "BulkCreateStream"
+	BulkDelete       = "BulkDelete"
+	BulkDeleteStream = "BulkDeleteStream"
+)
+
+// ErrUnexpectedCall Returned by calls matching no expectation.
+var ErrUnexpectedCall = errors.New("accountsmock: unexpected call")
+
+// Any Argument matching any value.
+var Any = anyArg{}
+
+type anyArg struct{}
+
+// TestingT Subset of testing.T used to report unmet expectations.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// Call Call received by the mock.
+type Call struct {
+	Method string
+	Args   []interface{}
+	Ctx    context.Context
+}
+
+// Expectation Programmed behaviour of the calls to a method with matching arguments.
+type Expectation struct {
+	method string
+	args   []interface{}
+	resp   interface{}
+	err    error
+	run    func(args []interface{})
+	times  int
+	calls  int
+}
+
+// Return Sets the response and error returned by the matching calls. The response must be a
+// *organisation_api.ClientResponse, or a *organisation_api.ListResponse for ListAccounts. For the AccountsService
+// methods it must be a *organisation_api.AccountData, a *organisation_api.ListResponse for List, and a
+// []organisation_api.BulkResult for the bulk methods. The iterator methods take the []organisation_api.AccountData
+// they walk, the error being reported by their Err once the accounts are consumed.
+func (e *Expectation) Return(resp interface{}, err error) *Expectation {
+	e.resp = resp
+	e.err = err
+	return e
+}
+
+// ReturnError Makes the matching calls fail with the given error.
+func (e *Expectation) ReturnError(err error) *Expectation {
+	return e.Return(nil, err)
+}
+
+// Run Calls fn with the arguments of every matching call, before returning, e.g. to apply an AccountMutation.
+func (e *Expectation) Run(fn func(args []interface{})) *Expectation {
+	e.run = fn
+	return e
+}
+
+// Times Limits the expectation to n calls, after which the following expectations are matched. AssertExpectations
+// checks it was called exactly n times. By default an expectation matches any number of calls, and at least one is
+// expected.
+func (e *Expectation) Times(n int) *Expectation {
+	e.times = n
+	return e
+}
+
+// Once Same as Times(1).
+func (e *Expectation) Once() *Expectation {
+	return e.Times(1)
+}
+
+func (e *Expectation) matches(method string, args []interface{}) bool {
+	if e.method != method || (e.times > 0 && e.calls >= e.times) {
+		return false
+	}
+	if e.args == nil {
+		return true
+	}
+	if len(e.args) != len(args) {
+		return false
+	}
+
+	for i, expected := range e.args {
+		if _, ok := expected.(anyArg); ok {
+			continue
+		}
+		if !reflect.DeepEqual(expected, args[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Mock Programmable organisation_api.AccountsAPI. Safe for concurrent use.
+type Mock struct {
+	mu           sync.Mutex
+	expectations []*Expectation
+	calls        []Call
+}
+
+var (
+	_ organisation_api.AccountsAPI     = (*Mock)(nil)
+	_ organisation_api.AccountsService = (*Mock)(nil)
+)
+
+// New Builds a mock without expectations, failing every call with ErrUnexpectedCall.
+func New() *Mock {
+	return &Mock{}
+}
+
+// On Expects calls to the method with the given arguments, Any matching any value. Without arguments, calls with any
+// arguments match.
+func (m *Mock) On(method string, args ...interface{}) *Expectation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e := &Expectation{method: method, args: args}
+	m.expectations = append(m.expectations, e)
+
+	return e
+}
+
+// Calls Returns the calls received so far, in order.
+func (m *Mock) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Call(nil), m.calls...)
+}
+
+// CallsTo Returns the calls received so far by the method, in order.
+func (m *Mock) CallsTo(method string) []Call {
+	var calls []Call
+	for _, c := range m.Calls() {
+		if c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+
+	return calls
+}
+
+// AssertExpectations Reports the expectations which weren't called, or not the number of times set.
+func (m *Mock) AssertExpectations(t TestingT) bool {
+	t.Helper()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ok := true
+	for _, e := range m.expectations {
+		if (e.times > 0 && e.calls != e.times) || (e.times == 0 && e.calls == 0) {
+			t.Errorf("accountsmock: expected %s%v to be called %s, got %d calls", e.method, e.args, describeTimes(e.times), e.calls)
+			ok = false
+		}
+	}
+
+	return ok
+}
+
+func describeTimes(times int) string {
+	if times == 0 {
+		return "at least once"
+	}
+
+	return fmt.Sprintf("%d times", times)
+}
+
+// call Records the call and returns the behaviour of the first matching expectation.
+func (m *Mock) call(ctx context.Context, method string, args ...interface{}) (interface{}, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: method, Args: args, Ctx: ctx})
+
+	var matched *Expectation
+	for _, e := range m.expectations {
+		if e.matches(method, args) {
+			matched = e
+			matched.calls++
+			break
+		}
+	}
+	m.mu.Unlock()
+
+	if matched == nil {
+		return nil, fmt.Errorf("%w: %s%v", ErrUnexpectedCall, method, args)
+	}
+	if matched.run != nil {
+		matched.run(args)
+	}
+
+	return matched.resp, matched.err
+}
+
+// response Returns the behaviour of the call, panicking when the programmed response is not of the type of target,
+// a pointer to the returned value.
+func (m *Mock) response(ctx context.Context, target interface{}, method string, args ...interface{}) error {
+	resp, err := m.call(ctx, method, args...)
+	if resp == nil {
+		return err
+	}
+
+	v := reflect.ValueOf(target).Elem()
+	if !reflect.TypeOf(resp).AssignableTo(v.Type()) {
+		panic(fmt.Sprintf("accountsmock: %s must return a %s, got %T", method, v.Type(), resp))
+	}
+	v.Set(reflect.ValueOf(resp))
+
+	return err
+}
+
+func (m *Mock) clientResponse(ctx context.Context, method string, args ...interface{}) (*organisation_api.ClientResponse, error) {
+	var resp *organisation_api.ClientResponse
+	err := m.response(ctx, &resp, method, args...)
+
+	return resp, err
+}
+
+// CreateAccount Records the call with a background context.
+func (m *Mock) CreateAccount(data organisation_api.AccountData) (*organisation_api.ClientResponse, error) {
+	return m.CreateAccountWithContext(data, context.Background())
+}
+
+// CreateAccountWithContext Records the call as CreateAccount, with its context.
+func (m *Mock) CreateAccountWithContext(data organisation_api.AccountData, ctx context.Context) (*organisation_api.ClientResponse, error) {
+	return m.clientResponse(ctx, CreateAccount, data)
+}
+
+// FetchAccount Records the call with a background context.
+func (m *Mock) FetchAccount(id string) (*organisation_api.ClientResponse, error) {
+	return m.FetchAccountWithContext(id, context.Background())
+}
+
+// FetchAccountWithContext Records the call as FetchAccount, with its context.
+func (m *Mock) FetchAccountWithContext(id string, ctx context.Context) (*organisation_api.ClientResponse, error) {
+	return m.clientResponse(ctx, FetchAccount, id)
+}
+
+// DeleteAccount Records the call with a background context.
+func (m *Mock) DeleteAccount(id string, version int64) (*organisation_api.ClientResponse, error) {
+	return m.DeleteAccountWithContext(id, version, context.Background())
+}
+
+// DeleteAccountWithContext Records the call as DeleteAccount, with its context.
+func (m *Mock) DeleteAccountWithContext(id string, version int64, ctx context.Context) (*organisation_api.ClientResponse, error) {
+	return m.clientResponse(ctx, DeleteAccount, id, version)
+}
+
+// ListAccounts Records the call with a background context.
+func (m *Mock) ListAccounts(opts organisation_api.ListOptions) (*organisation_api.ListResponse, error) {
+	return m.ListAccountsWithContext(opts, context.Background())
+}
+
+// ListAccountsWithContext Records the call as ListAccounts, with its context.
+func (m *Mock) ListAccountsWithContext(opts organisation_api.ListOptions, ctx context.Context) (*organisation_api.ListResponse, error) {
+	var resp *organisation_api.ListResponse
+	err := m.response(ctx, &resp, ListAccounts, opts)
+
+	return resp, err
+}
+
+// UpdateAccount Records the call with a background context.
+func (m *Mock) UpdateAccount(data organisation_api.AccountData) (*organisation_api.ClientResponse, error) {
+	return m.UpdateAccountWithContext(data, context.Background())
+}
+
+// UpdateAccountWithContext Records the call as UpdateAccount, with its context.
+func (m *Mock) UpdateAccountWithContext(data organisation_api.AccountData, ctx context.Context) (*organisation_api.ClientResponse, error) {
+	return m.clientResponse(ctx, UpdateAccount, data)
+}
+
+// UpdateAccountWithRetry Records the call with a background context.
+func (m *Mock) UpdateAccountWithRetry(id string, mutate organisation_api.AccountMutation, maxAttempts int) (*organisation_api.ClientResponse, error) {
+	return m.UpdateAccountWithRetryWithContext(id, mutate, maxAttempts, context.Background())
+}
+
+// UpdateAccountWithRetryWithContext Records the call as UpdateAccountWithRetry, with its context.
+func (m *Mock) UpdateAccountWithRetryWithContext(id string, mutate organisation_api.AccountMutation, maxAttempts int, ctx context.Context) (*organisation_api.ClientResponse, error) {
+	return m.clientResponse(ctx, UpdateAccountWithRetry, id, mutate, maxAttempts)
+}
+
+// IterateAccounts Records the call with a background context.
+func (m *Mock) IterateAccounts(opts organisation_api.ListOptions) *organisation_api.AccountIterator {
+	return m.IterateAccountsWithContext(opts, context.Background())
+}
+
+// IterateAccountsWithContext Records the call as IterateAccounts, with its context.
+func (m *Mock) IterateAccountsWithContext(opts organisation_api.ListOptions, ctx context.Context) *organisation_api.AccountIterator {
+	return m.iterator(ctx, IterateAccounts, opts)
+}
+
+func (m *Mock) iterator(ctx context.Context, method string, args ...interface{}) *organisation_api.AccountIterator {
+	var accounts []organisation_api.AccountData
+	err := m.response(ctx, &accounts, method, args...)
+
+	return organisation_api.NewAccountIterator(accounts, err)
+}
+
+func (m *Mock) account(ctx context.Context, method string, args ...interface{}) (*organisation_api.AccountData, error) {
+	var data *organisation_api.AccountData
+	err := m.response(ctx, &data, method, args...)
+
+	return data, err
+}
+
+func (m *Mock) bulk(ctx context.Context, method string, args ...interface{}) ([]organisation_api.BulkResult, error) {
+	var results []organisation_api.BulkResult
+	err := m.response(ctx, &results, method, args...)
+
+	return results, err
+}
+
+// Create Records the call.
+func (m *Mock) Create(ctx context.Context, data organisation_api.AccountData) (*organisation_api.AccountData, error) {
+	return m.account(ctx, Create, data)
+}
+
+// Get Records the call.
+func (m *Mock) Get(ctx context.Context, id string) (*organisation_api.AccountData, error) {
+	return m.account(ctx, Get, id)
+}
+
+// Delete Records the call.
+func (m *Mock) Delete(ctx context.Context, id string, version int64) error {
+	_, err := m.call(ctx, Delete, id, version)
+	return err
+}
+
+// Update Records the call.
+func (m *Mock) Update(ctx context.Context, data organisation_api.AccountData) (*organisation_api.AccountData, error) {
+	return m.account(ctx, Update, data)
+}
+
+// UpdateWithRetry Records the call.
+func (m *Mock) UpdateWithRetry(ctx context.Context, id string, mutate organisation_api.AccountMutation, maxAttempts int) (*organisation_api.AccountData, error) {
+	return m.account(ctx, UpdateWithRetry, id, mutate, maxAttempts)
+}
+
+// List Records the call.
+func (m *Mock) List(ctx context.Context, opts organisation_api.ListOptions) ([]organisation_api.AccountData, *organisation_api.Links, error) {
+	var resp *organisation_api.ListResponse
+	err := m.response(ctx, &resp, List, opts)
+	if resp == nil {
+		return nil, nil, err
+	}
+
+	return resp.Data, resp.Links, err
+}
+
+// Iterate Records the call.
+func (m *Mock) Iterate(ctx context.Context, opts organisation_api.ListOptions) *organisation_api.AccountIterator {
+	return m.iterator(ctx, Iterate, opts)
+}
+
+// BulkCreate Records the call.
+func (m *Mock) BulkCreate(ctx context.Context, accounts []organisation_api.AccountData, opts organisation_api.BulkOptions) ([]organisation_api.BulkResult, error) {
+	return m.bulk(ctx, BulkCreate, accounts, opts)
+}
+
+// BulkCreateStream Records the call. The channel is left to the Run function of the expectation, if any.
+func (m *Mock) BulkCreateStream(ctx context.Context, accounts <-chan organisation_api.AccountData, opts organisation_api.BulkOptions) ([]organisation_api.BulkResult, error) {
+	return m.bulk(ctx, BulkCreateStream, accounts, opts)
+}
+
+// BulkDelete Records the call.
+func (m *Mock) BulkDelete(ctx context.Context, accounts []organisation_api.AccountVersion, opts organisation_api.BulkOptions) ([]organisation_api.BulkResult, error) {
+	return m.bulk(ctx, BulkDelete, accounts, opts)
+}
+
+// BulkDeleteStream Records the call. The channel is left to the Run function of the expectation, if any.
+func (m *Mock) BulkDeleteStream(ctx context.Context, accounts <-chan organisation_api.AccountVersion, opts organisation_api.BulkOptions) ([]organisation_api.BulkResult, error) {
+	return m.bulk(ctx, BulkDeleteStream, accounts, opts)
+}
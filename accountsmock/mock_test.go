@@ -0,0 +1,149 @@
+package accountsmock_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	organisation_api "github.com/CG-SS/organisation-api"
+	"github.com/CG-SS/organisation-api/accountsmock"
+)
+
+const accountID = "123e4567-e89b-12d3-a456-426614174129"
+
+// closeAccount Consumer code under test, depending on the interface only.
+func closeAccount(api organisation_api.AccountsAPI, ctx context.Context, id string) error {
+	resp, err := api.FetchAccountWithContext(id, ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = api.DeleteAccountWithContext(id, *resp.Data.Version, ctx)
+	return err
+}
+
+func TestMock_expectations(t *testing.T) {
+	version := int64(3)
+	m := accountsmock.New()
+	m.On(accountsmock.FetchAccount, accountID).
+		Return(&organisation_api.ClientResponse{Data: &organisation_api.AccountData{ID: accountID, Version: &version}}, nil)
+	m.On(accountsmock.DeleteAccount, accountsmock.Any, int64(3)).Once().
+		Return(&organisation_api.ClientResponse{StatusCode: 204, Success: true}, nil)
+
+	ctx := context.WithValue(context.Background(), struct{}{}, "caller")
+	if err := closeAccount(m, ctx, accountID); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := m.Calls()
+	if len(calls) != 2 || calls[1].Method != accountsmock.DeleteAccount || calls[1].Args[1] != int64(3) || calls[1].Ctx != ctx {
+		t.Fatal("Calls weren't recorded! Got", calls)
+	}
+	if !m.AssertExpectations(t) {
+		t.Fatal("Expectations should've been met")
+	}
+
+	// the delete expectation was used up
+	if err := closeAccount(m, ctx, accountID); !errors.Is(err, accountsmock.ErrUnexpectedCall) {
+		t.Fatal("Expected unexpected call error, got", err)
+	}
+}
+
+func TestMock_cannedErrorsAndRun(t *testing.T) {
+	m := accountsmock.New()
+	m.On(accountsmock.FetchAccount).ReturnError(organisation_api.ErrNotFound)
+	m.On(accountsmock.UpdateAccountWithRetry).Run(func(args []interface{}) {
+		data := &organisation_api.AccountData{}
+		if err := args[1].(organisation_api.AccountMutation)(data); err != nil {
+			t.Error(err)
+		}
+	}).Return(&organisation_api.ClientResponse{Success: true}, nil)
+
+	if _, err := m.FetchAccount("any-id"); !errors.Is(err, organisation_api.ErrNotFound) {
+		t.Fatal("Expected canned error, got", err)
+	}
+
+	mutated := false
+	if _, err := m.UpdateAccountWithRetry(accountID, func(data *organisation_api.AccountData) error {
+		mutated = true
+		return nil
+	}, 3); err != nil || !mutated {
+		t.Fatal("Expected mutation to be run, got", err)
+	}
+
+	if len(m.CallsTo(accountsmock.FetchAccount)) != 1 {
+		t.Fatal("Expected a single fetch call, got", m.CallsTo(accountsmock.FetchAccount))
+	}
+}
+
+func TestMock_unmetExpectations(t *testing.T) {
+	m := accountsmock.New()
+	m.On(accountsmock.CreateAccount)
+	m.On(accountsmock.ListAccounts).Times(2).Return(&organisation_api.ListResponse{}, nil)
+
+	if _, err := m.ListAccounts(organisation_api.ListOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := &recordingT{}
+	if m.AssertExpectations(rec) || len(rec.errors) != 2 {
+		t.Fatal("Expected 2 unmet expectations, got", rec.errors)
+	}
+}
+
+// countAccounts Consumer code under test, depending on the context-first interface only.
+func countAccounts(accounts organisation_api.AccountsService, ctx context.Context) (int, error) {
+	n := 0
+	it := accounts.Iterate(ctx, organisation_api.ListOptions{})
+	for it.Next() {
+		n++
+	}
+
+	return n, it.Err()
+}
+
+func TestMock_accountsService(t *testing.T) {
+	m := accountsmock.New()
+	m.On(accountsmock.Get, accountID).Return(&organisation_api.AccountData{ID: accountID}, nil)
+	m.On(accountsmock.Delete).ReturnError(organisation_api.ErrNotFound)
+	m.On(accountsmock.Iterate).Once().Return([]organisation_api.AccountData{{ID: "1"}, {ID: "2"}}, nil)
+	m.On(accountsmock.Iterate).Return([]organisation_api.AccountData{{ID: "1"}}, errors.New("page fetch failed"))
+	m.On(accountsmock.BulkCreate).Return([]organisation_api.BulkResult{{Index: 0, ID: accountID}}, nil)
+
+	var accounts organisation_api.AccountsService = m
+	ctx := context.Background()
+
+	if data, err := accounts.Get(ctx, accountID); err != nil || data.ID != accountID {
+		t.Fatal("Expected the programmed account, got", data, err)
+	}
+	if err := accounts.Delete(ctx, accountID, 0); !errors.Is(err, organisation_api.ErrNotFound) {
+		t.Fatal("Expected canned error, got", err)
+	}
+	if n, err := countAccounts(accounts, ctx); n != 2 || err != nil {
+		t.Fatal("Expected 2 accounts, got", n, err)
+	}
+	if n, err := countAccounts(accounts, ctx); n != 1 || err == nil {
+		t.Fatal("Expected the iteration to fail after 1 account, got", n, err)
+	}
+	if results, err := accounts.BulkCreate(ctx, []organisation_api.AccountData{{ID: accountID}}, organisation_api.BulkOptions{}); err != nil || len(results) != 1 {
+		t.Fatal("Expected the programmed results, got", results, err)
+	}
+
+	if len(m.CallsTo(accountsmock.Iterate)) != 2 || m.CallsTo(accountsmock.Get)[0].Ctx != ctx {
+		t.Fatal("Calls weren't recorded! Got", m.Calls())
+	}
+	if !m.AssertExpectations(t) {
+		t.Fatal("Expectations should've been met")
+	}
+}
+
+type recordingT struct {
+	errors []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,60 @@
+package organisation_api
+
+import "context"
+
+// AccountsService Context-first API of the accounts resource, returned by OrganisationApiClient.Accounts, returning
+// the account data itself. Failures are returned as the same typed errors as the v1 methods, e.g. an *APIError
+// matching ErrNotFound. Consumers can replace it in their tests, e.g. with accountsmock.Mock.
+type AccountsService interface {
+	// Create Creates the account. When ResolveCreateConflicts is set, an identical pre-existing account is returned
+	// as is.
+	Create(ctx context.Context, data AccountData) (*AccountData, error)
+	// Get Fetches the account with the given id.
+	Get(ctx context.Context, id string) (*AccountData, error)
+	// Delete Deletes the given version of the account.
+	Delete(ctx context.Context, id string, version int64) error
+	// Update Patches the account, which must carry its current version.
+	Update(ctx context.Context, data AccountData) (*AccountData, error)
+	// UpdateWithRetry Fetches the account, applies the mutation and patches it, repeating the cycle on version
+	// conflicts up to maxAttempts times.
+	UpdateWithRetry(ctx context.Context, id string, mutate AccountMutation, maxAttempts int) (*AccountData, error)
+	// List Lists a page of the accounts matching the options, with the links to the other pages.
+	List(ctx context.Context, opts ListOptions) ([]AccountData, *Links, error)
+	// Iterate Returns an iterator over every account matching the options. No request is made until Next is called.
+	Iterate(ctx context.Context, opts ListOptions) *AccountIterator
+	// BulkCreate Creates the accounts concurrently, see BulkCreateStream.
+	BulkCreate(ctx context.Context, accounts []AccountData, opts BulkOptions) ([]BulkResult, error)
+	// BulkCreateStream Creates the accounts received from the channel until it is closed, through a bounded worker
+	// pool. Returns the results of the attempted operations, ordered by their position in the input, and a *BulkError
+	// when any failed or the bulk stopped early because of the context or the failure threshold.
+	BulkCreateStream(ctx context.Context, accounts <-chan AccountData, opts BulkOptions) ([]BulkResult, error)
+	// BulkDelete Deletes the account versions concurrently, see BulkDeleteStream.
+	BulkDelete(ctx context.Context, accounts []AccountVersion, opts BulkOptions) ([]BulkResult, error)
+	// BulkDeleteStream Deletes the account versions received from the channel until it is closed, with the same
+	// behaviour as BulkCreateStream.
+	BulkDeleteStream(ctx context.Context, accounts <-chan AccountVersion, opts BulkOptions) ([]BulkResult, error)
+}
+
+var _ AccountsService = (*accountsService)(nil)
+
+// AccountsAPI Account operations of OrganisationApiClient, for consumers to depend on instead of the client so they
+// can replace it in their tests, e.g. with accountsmock.Mock. Accounts is left out, consumers of the context-first API
+// depending on AccountsService instead.
+type AccountsAPI interface {
+	CreateAccount(data AccountData) (*ClientResponse, error)
+	CreateAccountWithContext(data AccountData, ctx context.Context) (*ClientResponse, error)
+	FetchAccount(id string) (*ClientResponse, error)
+	FetchAccountWithContext(id string, ctx context.Context) (*ClientResponse, error)
+	DeleteAccount(id string, version int64) (*ClientResponse, error)
+	DeleteAccountWithContext(id string, version int64, ctx context.Context) (*ClientResponse, error)
+	ListAccounts(opts ListOptions) (*ListResponse, error)
+	ListAccountsWithContext(opts ListOptions, ctx context.Context) (*ListResponse, error)
+	IterateAccounts(opts ListOptions) *AccountIterator
+	IterateAccountsWithContext(opts ListOptions, ctx context.Context) *AccountIterator
+	UpdateAccount(data AccountData) (*ClientResponse, error)
+	UpdateAccountWithContext(data AccountData, ctx context.Context) (*ClientResponse, error)
+	UpdateAccountWithRetry(id string, mutate AccountMutation, maxAttempts int) (*ClientResponse, error)
+	UpdateAccountWithRetryWithContext(id string, mutate AccountMutation, maxAttempts int, ctx context.Context) (*ClientResponse, error)
+}
+
+var _ AccountsAPI = (*OrganisationApiClient)(nil)
@@ -0,0 +1,271 @@
+package organisation_api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultTokenExpiryDelta = 30 * time.Second
+	// defaultTokenLifetime Lifetime assumed for tokens returned without expires_in.
+	defaultTokenLifetime = time.Hour
+	defaultTokenTimeout  = 10 * time.Second
+)
+
+// OAuth2Config Settings of the OAuth2 client credentials grant.
+type OAuth2Config struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	// ExpiryDelta Tokens are refreshed this long before they expire. Defaults to 30s.
+	ExpiryDelta time.Duration
+	// HTTPClient Client used to call the token endpoint. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+	// RequestTimeout Timeout of a token request, which doesn't depend on the callers waiting for it. Defaults to 10s.
+	RequestTimeout time.Duration
+}
+
+func (c OAuth2Config) validate() error {
+	if c.ClientID == "" || c.ClientSecret == "" {
+		return errors.New("organisation api: oauth2 client ID and secret are required")
+	}
+	if _, err := parseRootUrl(c.TokenURL); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// oauth2Token Token returned by the token endpoint.
+type oauth2Token struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	expiry      time.Time
+}
+
+// oauth2ErrorBody Error response of the token endpoint, as defined by RFC 6749.
+type oauth2ErrorBody struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// tokenCall In-flight token request shared by every concurrent caller.
+type tokenCall struct {
+	done  chan struct{}
+	token *oauth2Token
+	err   error
+}
+
+// OAuth2Transport http.RoundTripper authenticating requests with bearer tokens obtained through the OAuth2 client
+// credentials grant. Tokens are cached until shortly before they expire and refreshed by a single request, however
+// many requests need them concurrently. A 401 response makes it refresh the token and retry the request once.
+type OAuth2Transport struct {
+	Base   http.RoundTripper
+	config OAuth2Config
+
+	mu       sync.Mutex
+	token    *oauth2Token
+	inflight *tokenCall
+	now      func() time.Time
+}
+
+// NewOAuth2Transport Builds the transport, sending the authenticated requests through base, or
+// http.DefaultTransport when nil.
+func NewOAuth2Transport(config OAuth2Config, base http.RoundTripper) (*OAuth2Transport, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	if config.ExpiryDelta <= 0 {
+		config.ExpiryDelta = defaultTokenExpiryDelta
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+	if config.RequestTimeout <= 0 {
+		config.RequestTimeout = defaultTokenTimeout
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &OAuth2Transport{
+		Base:   base,
+		config: config,
+		now:    time.Now,
+	}, nil
+}
+
+// RoundTrip Sends the request with a bearer token, refreshing it and retrying once on 401.
+func (t *OAuth2Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.Token(req.Context())
+	if err != nil {
+		closeRequestBody(req)
+		return nil, err
+	}
+
+	resp, err := t.Base.RoundTrip(authorize(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// the body was consumed by the first attempt, so only replayable requests can be retried
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return resp, nil
+	}
+	drainBody(resp.Body)
+
+	t.invalidate(token)
+	token, err = t.Token(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	retry := authorize(req, token)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retry.Body = body
+	}
+
+	return t.Base.RoundTrip(retry)
+}
+
+// Token Returns a valid access token, requesting a new one when the cached token is missing or about to expire. The
+// context only bounds the wait of this caller, the token request being shared with the concurrent ones.
+func (t *OAuth2Transport) Token(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	if t.token != nil && t.now().Add(t.config.ExpiryDelta).Before(t.token.expiry) {
+		token := t.token.AccessToken
+		t.mu.Unlock()
+		return token, nil
+	}
+
+	call := t.inflight
+	leader := call == nil
+	if leader {
+		call = &tokenCall{done: make(chan struct{})}
+		t.inflight = call
+	}
+	t.mu.Unlock()
+
+	if leader {
+		go t.fetch(call)
+	}
+
+	select {
+	case <-call.done:
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+
+	if call.err != nil {
+		return "", call.err
+	}
+
+	return call.token.AccessToken, nil
+}
+
+// fetch Requests the token of the call on a context of its own, so that the caller which started it giving up
+// doesn't fail the others waiting for it.
+func (t *OAuth2Transport) fetch(call *tokenCall) {
+	ctx, cancel := context.WithTimeout(context.Background(), t.config.RequestTimeout)
+	defer cancel()
+
+	call.token, call.err = t.requestToken(ctx)
+
+	t.mu.Lock()
+	if call.err == nil {
+		t.token = call.token
+	}
+	t.inflight = nil
+	t.mu.Unlock()
+	close(call.done)
+}
+
+// invalidate Drops the cached token if it is still the rejected one, so concurrent 401s cause a single refresh.
+func (t *OAuth2Transport) invalidate(rejected string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != nil && t.token.AccessToken == rejected {
+		t.token = nil
+	}
+}
+
+func (t *OAuth2Transport) requestToken(ctx context.Context) (*oauth2Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	if len(t.config.Scopes) > 0 {
+		form.Set("scope", strings.Join(t.config.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.config.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(t.config.ClientID), url.QueryEscape(t.config.ClientSecret))
+
+	issuedAt := t.now()
+	resp, err := t.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(resp.Body)
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Body: b}
+		eb := oauth2ErrorBody{}
+		if json.Unmarshal(b, &eb) == nil {
+			apiErr.ErrorCode = eb.Error
+			apiErr.Message = eb.ErrorDescription
+		}
+		return nil, apiErr
+	}
+
+	token := &oauth2Token{}
+	if err := json.Unmarshal(b, token); err != nil {
+		return nil, err
+	}
+	if token.AccessToken == "" {
+		return nil, errors.New("organisation api: token endpoint returned no access token")
+	}
+	lifetime := time.Duration(token.ExpiresIn) * time.Second
+	if lifetime <= 0 {
+		lifetime = defaultTokenLifetime
+	}
+	token.expiry = issuedAt.Add(lifetime)
+
+	return token, nil
+}
+
+// authorize Clones the request with the bearer token, as RoundTrippers must not modify the request they are given.
+func authorize(req *http.Request, token string) *http.Request {
+	r := req.Clone(req.Context())
+	r.Header.Set("Authorization", "Bearer "+token)
+
+	return r
+}
+
+func closeRequestBody(req *http.Request) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+}
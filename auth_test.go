@@ -0,0 +1,251 @@
+//go:build !integration
+// +build !integration
+
+package organisation_api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// tokenServer Token endpoint handing out sequential tokens, counting the requests it receives.
+func tokenServer(t *testing.T, expiresIn int, requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(requests, 1)
+
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != "client" || secret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":"invalid_client","error_description":"bad credentials"}`)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+			return
+		}
+		if r.PostForm.Get("grant_type") != "client_credentials" || r.PostForm.Get("scope") != "accounts:read accounts:write" {
+			t.Error("Wrong token request! Got", r.PostForm)
+			return
+		}
+
+		// slow enough for concurrent callers to pile up behind the first request
+		time.Sleep(10 * time.Millisecond)
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer","expires_in":%d}`, n, expiresIn)
+	}))
+}
+
+func newOAuth2TestClient(t *testing.T, tokenUrl string, api roundTripAux, secret string) *OAuth2Transport {
+	transport, err := NewOAuth2Transport(OAuth2Config{
+		TokenURL:     tokenUrl,
+		ClientID:     "client",
+		ClientSecret: secret,
+		Scopes:       []string{"accounts:read", "accounts:write"},
+	}, api)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return transport
+}
+
+func okResponse() *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusNoContent,
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+	}
+}
+
+func TestOAuth2Transport_cachesTokenAcrossConcurrentRequests(t *testing.T) {
+	var tokenRequests int32
+	ts := tokenServer(t, 3600, &tokenRequests)
+	defer ts.Close()
+
+	transport := newOAuth2TestClient(t, ts.URL, func(r *http.Request) (*http.Response, error) {
+		if r.Header.Get("Authorization") != "Bearer token-1" {
+			return nil, errors.New("wrong token " + r.Header.Get("Authorization"))
+		}
+		return okResponse(), nil
+	}, "secret")
+
+	c, err := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.DeleteAccount(mockAccountData.ID, 0); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatal("Got client error", err)
+	}
+	if tokenRequests != 1 {
+		t.Fatal("Expected a single token request, got", tokenRequests)
+	}
+}
+
+func TestOAuth2Transport_cancelledCallerDoesNotFailOthers(t *testing.T) {
+	var tokenRequests int32
+	ts := tokenServer(t, 3600, &tokenRequests)
+	defer ts.Close()
+
+	transport := newOAuth2TestClient(t, ts.URL, nil, "secret")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := transport.Token(ctx)
+		leaderErr <- err
+	}()
+
+	// the follower joins the token request started by the first caller, which then gives up
+	time.Sleep(2 * time.Millisecond)
+	go func() {
+		time.Sleep(time.Millisecond)
+		cancel()
+	}()
+	token, err := transport.Token(context.Background())
+	if err != nil || token != "token-1" {
+		t.Fatal("Expected the follower to get the token, got", token, err)
+	}
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Fatal("Expected the cancelled caller to stop waiting, got", err)
+	}
+	if n := atomic.LoadInt32(&tokenRequests); n != 1 {
+		t.Fatal("Expected a single token request, got", n)
+	}
+}
+
+func TestOAuth2Transport_refreshesBeforeExpiry(t *testing.T) {
+	var tokenRequests int32
+	ts := tokenServer(t, 60, &tokenRequests)
+	defer ts.Close()
+
+	transport := newOAuth2TestClient(t, ts.URL, func(r *http.Request) (*http.Response, error) {
+		return okResponse(), nil
+	}, "secret")
+
+	now := time.Now()
+	transport.now = func() time.Time { return now }
+
+	for _, elapsed := range []time.Duration{0, 20 * time.Second, 31 * time.Second} {
+		now = now.Add(elapsed)
+		req, err := http.NewRequest(http.MethodGet, "http://localhost/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := transport.RoundTrip(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 51s after issuing a 60s token is within the default 30s expiry delta
+	if tokenRequests != 2 {
+		t.Fatal("Expected 2 token requests, got", tokenRequests)
+	}
+}
+
+func TestOAuth2Transport_retriesOnceOnUnauthorized(t *testing.T) {
+	var tokenRequests int32
+	ts := tokenServer(t, 3600, &tokenRequests)
+	defer ts.Close()
+
+	apiRequests := 0
+	var bodies []string
+	transport := newOAuth2TestClient(t, ts.URL, func(r *http.Request) (*http.Response, error) {
+		apiRequests++
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bodies = append(bodies, string(b))
+
+		// the first token gets revoked server side
+		if r.Header.Get("Authorization") == "Bearer token-1" {
+			return &http.Response{
+				StatusCode: http.StatusUnauthorized,
+				Body:       ioutil.NopCloser(strings.NewReader("")),
+			}, nil
+		}
+		return okResponse(), nil
+	}, "secret")
+
+	req, err := http.NewRequest(http.MethodPost, "http://localhost/", strings.NewReader("payload"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != http.StatusNoContent || apiRequests != 2 || tokenRequests != 2 {
+		t.Fatal("Expected a single retry with a new token, got status", resp.StatusCode, "after", apiRequests, "requests")
+	}
+	if bodies[0] != "payload" || bodies[1] != "payload" {
+		t.Fatal("Body wasn't replayed! Got", bodies)
+	}
+}
+
+func TestOAuth2Transport_tokenErrors(t *testing.T) {
+	var tokenRequests int32
+	ts := tokenServer(t, 3600, &tokenRequests)
+	defer ts.Close()
+
+	transport := newOAuth2TestClient(t, ts.URL, func(r *http.Request) (*http.Response, error) {
+		t.Fatal("Request was sent without a token!")
+		return nil, nil
+	}, "wrong")
+
+	req, err := http.NewRequest(http.MethodGet, "http://localhost/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = transport.RoundTrip(req)
+	var apiErr *APIError
+	if !errors.Is(err, ErrUnauthorized) || !errors.As(err, &apiErr) || apiErr.ErrorCode != "invalid_client" {
+		t.Fatal("Expected invalid client error, got", err)
+	}
+
+	if _, err := NewOAuth2Transport(OAuth2Config{TokenURL: "token"}, nil); err == nil {
+		t.Fatal("Expected config error")
+	}
+}
+
+type headerSigner struct{}
+
+func (headerSigner) Sign(r *http.Request) error {
+	r.Header.Set("Authorization", "Signature test")
+	return nil
+}
+
+func TestNewClient_rejectsSignerWithClientCredentials(t *testing.T) {
+	credentials := WithClientCredentials(OAuth2Config{TokenURL: "http://localhost/token", ClientID: "client", ClientSecret: "secret"})
+
+	if _, err := NewClient(WithBaseURL("http://localhost:8080/v1/organisation/"), WithSigner(headerSigner{}), credentials); err == nil {
+		t.Fatal("Expected the signer and client credentials to be rejected together")
+	}
+	if _, err := NewClient(WithBaseURL("http://localhost:8080/v1/organisation/"), credentials); err != nil {
+		t.Fatal("Expected client credentials alone to be accepted, got", err)
+	}
+}
@@ -0,0 +1,215 @@
+package organisation_api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen Returned without sending the request while the circuit breaker is open.
+var ErrCircuitOpen = errors.New("organisation api: circuit breaker is open")
+
+// CircuitState State of a CircuitBreaker.
+type CircuitState int
+
+const (
+	// CircuitClosed Requests flow, their failures being counted.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen Requests fail fast with ErrCircuitOpen until the cool-down elapses.
+	CircuitOpen
+	// CircuitHalfOpen A limited number of probe requests decide whether the circuit closes or opens again.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+
+	return "CircuitState(" + strconv.Itoa(int(s)) + ")"
+}
+
+// CircuitBreakerConfig Settings of a CircuitBreaker. Zero values are replaced by the defaults.
+type CircuitBreakerConfig struct {
+	// FailureRatio Ratio of failed requests opening the circuit. Defaults to 0.5.
+	FailureRatio float64
+	// MinRequests Number of requests in the window before the ratio is considered. Defaults to 10.
+	MinRequests int
+	// Window Period over which the requests are counted while closed. Defaults to 60s.
+	Window time.Duration
+	// CoolDown Time spent open before letting probes through. Defaults to 30s.
+	CoolDown time.Duration
+	// HalfOpenProbes Number of probe requests let through while half-open, all of which must succeed to close the
+	// circuit. Defaults to 1.
+	HalfOpenProbes int
+	// IsFailure Decides whether an attempt failed. Defaults to transport errors, timeouts included, and 5xx responses.
+	IsFailure func(resp *http.Response, err error) bool
+	// OnStateChange Called on every state change, outside of the breaker lock.
+	OnStateChange func(from, to CircuitState)
+}
+
+// CircuitBreaker Stops sending requests to an API that keeps failing, so callers fail fast instead of waiting for
+// timeouts, and probes it after a cool-down. Safe for concurrent use, and can be shared by several clients.
+type CircuitBreaker struct {
+	config CircuitBreakerConfig
+
+	mu          sync.Mutex
+	state       CircuitState
+	generation  uint64
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+	probes      int
+	successes   int
+	now         func() time.Time
+}
+
+// NewCircuitBreaker Builds a closed circuit breaker.
+func NewCircuitBreaker(config CircuitBreakerConfig) *CircuitBreaker {
+	if config.FailureRatio <= 0 || config.FailureRatio > 1 {
+		config.FailureRatio = 0.5
+	}
+	if config.MinRequests <= 0 {
+		config.MinRequests = 10
+	}
+	if config.Window <= 0 {
+		config.Window = time.Minute
+	}
+	if config.CoolDown <= 0 {
+		config.CoolDown = 30 * time.Second
+	}
+	if config.HalfOpenProbes <= 0 {
+		config.HalfOpenProbes = 1
+	}
+	if config.IsFailure == nil {
+		config.IsFailure = isServerFailure
+	}
+
+	return &CircuitBreaker{config: config, now: time.Now}
+}
+
+// State Returns the current state, moving to half-open when the cool-down has elapsed.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	transition := b.coolDown(b.now())
+	state := b.state
+	b.mu.Unlock()
+
+	b.notify(transition)
+	return state
+}
+
+// allow Returns the generation the attempt belongs to, or ErrCircuitOpen when it must not be sent.
+func (b *CircuitBreaker) allow() (uint64, error) {
+	b.mu.Lock()
+	now := b.now()
+	transition := b.coolDown(now)
+
+	var err error
+	switch b.state {
+	case CircuitClosed:
+		if now.Sub(b.windowStart) > b.config.Window {
+			b.windowStart = now
+			b.requests, b.failures = 0, 0
+		}
+	case CircuitOpen:
+		err = ErrCircuitOpen
+	case CircuitHalfOpen:
+		if b.probes >= b.config.HalfOpenProbes {
+			err = ErrCircuitOpen
+		} else {
+			b.probes++
+		}
+	}
+	generation := b.generation
+	b.mu.Unlock()
+
+	b.notify(transition)
+	return generation, err
+}
+
+// record Counts the outcome of an attempt of the given generation. Attempts abandoned by their caller, counted as
+// neither, only free their probe slot.
+func (b *CircuitBreaker) record(generation uint64, resp *http.Response, err error, abandoned bool) {
+	b.mu.Lock()
+	if generation != b.generation {
+		// sent before the last state change, it says nothing about the current state
+		b.mu.Unlock()
+		return
+	}
+
+	var transition []CircuitState
+	failed := !abandoned && b.config.IsFailure(resp, err)
+	switch b.state {
+	case CircuitClosed:
+		if abandoned {
+			break
+		}
+		b.requests++
+		if failed {
+			b.failures++
+		}
+		if b.requests >= b.config.MinRequests && float64(b.failures)/float64(b.requests) >= b.config.FailureRatio {
+			transition = b.setState(CircuitOpen)
+		}
+	case CircuitHalfOpen:
+		switch {
+		case abandoned:
+			b.probes--
+		case failed:
+			transition = b.setState(CircuitOpen)
+		default:
+			b.successes++
+			if b.successes >= b.config.HalfOpenProbes {
+				transition = b.setState(CircuitClosed)
+			}
+		}
+	}
+	b.mu.Unlock()
+
+	b.notify(transition)
+}
+
+// coolDown Moves an open circuit to half-open once the cool-down elapsed. Must be called with the lock held.
+func (b *CircuitBreaker) coolDown(now time.Time) []CircuitState {
+	if b.state == CircuitOpen && now.Sub(b.openedAt) >= b.config.CoolDown {
+		return b.setState(CircuitHalfOpen)
+	}
+
+	return nil
+}
+
+// setState Changes the state, resetting the counters, and returns the transition to notify once unlocked. Must be
+// called with the lock held.
+func (b *CircuitBreaker) setState(state CircuitState) []CircuitState {
+	from := b.state
+	b.state = state
+	b.generation++
+	b.requests, b.failures, b.probes, b.successes = 0, 0, 0, 0
+
+	now := b.now()
+	b.windowStart = now
+	if state == CircuitOpen {
+		b.openedAt = now
+	}
+
+	return []CircuitState{from, state}
+}
+
+func (b *CircuitBreaker) notify(transition []CircuitState) {
+	if transition != nil && b.config.OnStateChange != nil {
+		b.config.OnStateChange(transition[0], transition[1])
+	}
+}
+
+func isServerFailure(resp *http.Response, err error) bool {
+	return err != nil || resp.StatusCode >= http.StatusInternalServerError
+}
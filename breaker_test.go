@@ -0,0 +1,227 @@
+//go:build !integration
+// +build !integration
+
+package organisation_api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker_States(t *testing.T) {
+	var transitions []string
+	b := NewCircuitBreaker(CircuitBreakerConfig{
+		FailureRatio:   0.5,
+		MinRequests:    4,
+		CoolDown:       time.Second,
+		HalfOpenProbes: 2,
+		OnStateChange: func(from, to CircuitState) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	})
+	now := time.Now()
+	b.now = func() time.Time { return now }
+
+	failed := &http.Response{StatusCode: http.StatusInternalServerError}
+	ok := &http.Response{StatusCode: http.StatusOK}
+	attempt := func(resp *http.Response) error {
+		generation, err := b.allow()
+		if err == nil {
+			b.record(generation, resp, nil, false)
+		}
+		return err
+	}
+
+	for _, resp := range []*http.Response{ok, failed, ok, failed} {
+		if err := attempt(resp); err != nil {
+			t.Fatal("Expected closed circuit to let requests through, got", err)
+		}
+	}
+	if b.State() != CircuitOpen {
+		t.Fatal("Expected the failure ratio to open the circuit, got", b.State())
+	}
+	if err := attempt(ok); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatal("Expected open circuit to fail fast, got", err)
+	}
+
+	now = now.Add(time.Second)
+	if b.State() != CircuitHalfOpen {
+		t.Fatal("Expected the cool-down to half-open the circuit, got", b.State())
+	}
+	if err := attempt(failed); err != nil {
+		t.Fatal("Expected the probe to go through, got", err)
+	}
+	if b.State() != CircuitOpen {
+		t.Fatal("Expected a failed probe to open the circuit again, got", b.State())
+	}
+
+	now = now.Add(time.Second)
+	first, err := b.allow()
+	if err != nil {
+		t.Fatal("Expected the first probe to go through, got", err)
+	}
+	second, err := b.allow()
+	if err != nil {
+		t.Fatal("Expected the second probe to go through, got", err)
+	}
+	if _, err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatal("Expected the probes to be limited, got", err)
+	}
+	b.record(first, ok, nil, false)
+	b.record(second, ok, nil, false)
+	if b.State() != CircuitClosed {
+		t.Fatal("Expected successful probes to close the circuit, got", b.State())
+	}
+
+	expected := []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}
+	if len(transitions) != len(expected) {
+		t.Fatal("Expected transitions", expected, "got", transitions)
+	}
+	for i := range expected {
+		if transitions[i] != expected[i] {
+			t.Fatal("Expected transitions", expected, "got", transitions)
+		}
+	}
+}
+
+func TestCircuitBreaker_IgnoresStaleAndAbandonedAttempts(t *testing.T) {
+	b := NewCircuitBreaker(CircuitBreakerConfig{MinRequests: 1, CoolDown: time.Second})
+	now := time.Now()
+	b.now = func() time.Time { return now }
+
+	stale, _ := b.allow()
+	generation, _ := b.allow()
+	b.record(generation, nil, errors.New("connection refused"), false)
+	if b.State() != CircuitOpen {
+		t.Fatal("Expected the failure to open the circuit, got", b.State())
+	}
+
+	now = now.Add(time.Second)
+	probe, err := b.allow()
+	if err != nil {
+		t.Fatal("Expected the probe to go through, got", err)
+	}
+	b.record(stale, nil, errors.New("connection refused"), false)
+	if b.State() != CircuitHalfOpen {
+		t.Fatal("Expected attempts sent before the state change to be ignored, got", b.State())
+	}
+
+	b.record(probe, nil, context.Canceled, true)
+	if _, err := b.allow(); err != nil {
+		t.Fatal("Expected an abandoned probe to free its slot, got", err)
+	}
+}
+
+func TestCircuitBreaker_Concurrent(t *testing.T) {
+	var changes int32
+	b := NewCircuitBreaker(CircuitBreakerConfig{
+		MinRequests:   10,
+		CoolDown:      time.Millisecond,
+		OnStateChange: func(from, to CircuitState) { atomic.AddInt32(&changes, 1) },
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				generation, err := b.allow()
+				if err != nil {
+					continue
+				}
+				b.record(generation, &http.Response{StatusCode: 500 - (i%2)*300}, nil, false)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if atomic.LoadInt32(&changes) == 0 {
+		t.Fatal("Expected the circuit to change state")
+	}
+}
+
+func TestNewClient_WithCircuitBreaker(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{MinRequests: 2, CoolDown: time.Minute})
+	c, err := NewClient(WithBaseURL(ts.URL+"/v1/organisation/"), WithCircuitBreaker(breaker),
+		WithRetryPolicy(&RetryPolicy{MaxAttempts: 5, RetryableStatuses: DefaultRetryPolicy.RetryableStatuses}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.FetchAccount(mockAccountData.ID); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatal("Expected the retries to trip the circuit, got", err)
+	}
+	if _, err := c.FetchAccount(mockAccountData.ID); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatal("Expected open circuit error, got", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Fatal("Expected the open circuit to stop sending requests, got", n, "requests")
+	}
+}
+
+func TestNewClient_WithCircuitBreakerCountsDeadlines(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer ts.Close()
+
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{MinRequests: 3, CoolDown: time.Minute})
+	c, err := NewClient(WithBaseURL(ts.URL+"/v1/organisation/"), WithCircuitBreaker(breaker), WithRetryPolicy(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		_, err := c.FetchAccountWithContext(mockAccountData.ID, ctx)
+		cancel()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatal("Expected the deadline to expire, got", err)
+		}
+	}
+	if breaker.State() != CircuitOpen {
+		t.Fatal("Expected expired deadlines to open the circuit, got", breaker.State())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.FetchAccountWithContext(mockAccountData.ID, ctx); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatal("Expected open circuit error, got", err)
+	}
+}
+
+func TestCircuitBreaker_IgnoresCancelledRequests(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer ts.Close()
+
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{MinRequests: 1, CoolDown: time.Minute})
+	c, err := NewClient(WithBaseURL(ts.URL+"/v1/organisation/"), WithCircuitBreaker(breaker), WithRetryPolicy(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	if _, err := c.FetchAccountWithContext(mockAccountData.ID, ctx); !errors.Is(err, context.Canceled) {
+		t.Fatal("Expected the request to be cancelled, got", err)
+	}
+	if breaker.State() != CircuitClosed {
+		t.Fatal("Expected a cancelled request not to count as a failure, got", breaker.State())
+	}
+}
@@ -0,0 +1,195 @@
+package organisation_api
+
+import "fmt"
+
+// countryCurrencies Base currency filled in by AccountBuilder for the countries with specific Form3 rules.
+var countryCurrencies = map[Country]Currency{
+	CountryAU: CurrencyAUD,
+	CountryBE: CurrencyEUR,
+	CountryCA: CurrencyCAD,
+	CountryCH: CurrencyCHF,
+	CountryDE: CurrencyEUR,
+	CountryES: CurrencyEUR,
+	CountryFR: CurrencyEUR,
+	CountryGB: CurrencyGBP,
+	CountryGR: CurrencyEUR,
+	CountryHK: CurrencyHKD,
+	CountryIT: CurrencyEUR,
+	CountryLU: CurrencyEUR,
+	CountryNL: CurrencyEUR,
+	CountryPL: CurrencyPLN,
+	CountryPT: CurrencyEUR,
+	CountryUS: CurrencyUSD,
+}
+
+// AccountBuilder Builds an AccountData through chainable setters, e.g.
+//
+//	data, err := NewAccountBuilder().
+//		OrganisationID(orgID).
+//		Country(CountryGB).
+//		BankID("400302").
+//		Bic("NWBKGB42").
+//		Name("Kelvin", "Klein").
+//		Build()
+type AccountBuilder struct {
+	data  AccountData
+	attrs AccountAttributes
+}
+
+// NewAccountBuilder Starts an account with a random UUID as ID and "accounts" as type.
+func NewAccountBuilder() *AccountBuilder {
+	return &AccountBuilder{data: AccountData{ID: newUUID(), Type: accountsPath}}
+}
+
+// ID Replaces the generated ID.
+func (b *AccountBuilder) ID(id string) *AccountBuilder {
+	b.data.ID = id
+	return b
+}
+
+// OrganisationID Sets the organisation owning the account.
+func (b *AccountBuilder) OrganisationID(id string) *AccountBuilder {
+	b.data.OrganisationID = id
+	return b
+}
+
+// Version Sets the version, needed to update the account.
+func (b *AccountBuilder) Version(version int64) *AccountBuilder {
+	b.data.Version = Int64(version)
+	return b
+}
+
+// Country Sets the country. Unless set explicitly, Build fills in the bank_id_code and base_currency of the countries
+// with specific Form3 rules.
+func (b *AccountBuilder) Country(country Country) *AccountBuilder {
+	b.attrs.Country = country.Ptr()
+	return b
+}
+
+// Classification Sets the account classification.
+func (b *AccountBuilder) Classification(classification AccountClassification) *AccountBuilder {
+	b.attrs.AccountClassification = classification.Ptr()
+	return b
+}
+
+// Status Sets the account status.
+func (b *AccountBuilder) Status(status AccountStatus) *AccountBuilder {
+	b.attrs.Status = status.Ptr()
+	return b
+}
+
+// BankID Sets the bank identifier.
+func (b *AccountBuilder) BankID(bankID string) *AccountBuilder {
+	b.attrs.BankID = bankID
+	return b
+}
+
+// BankIDCode Sets the type of the bank identifier, overriding the country preset.
+func (b *AccountBuilder) BankIDCode(code BankIDCode) *AccountBuilder {
+	b.attrs.BankIDCode = code
+	return b
+}
+
+// BaseCurrency Sets the base currency, overriding the country preset.
+func (b *AccountBuilder) BaseCurrency(currency Currency) *AccountBuilder {
+	b.attrs.BaseCurrency = currency
+	return b
+}
+
+// Bic Sets the SWIFT BIC.
+func (b *AccountBuilder) Bic(bic string) *AccountBuilder {
+	b.attrs.Bic = bic
+	return b
+}
+
+// AccountNumber Sets the account number.
+func (b *AccountBuilder) AccountNumber(accountNumber string) *AccountBuilder {
+	b.attrs.AccountNumber = accountNumber
+	return b
+}
+
+// Iban Sets the IBAN.
+func (b *AccountBuilder) Iban(iban string) *AccountBuilder {
+	b.attrs.Iban = iban
+	return b
+}
+
+// Name Sets the names of the account holder.
+func (b *AccountBuilder) Name(names ...string) *AccountBuilder {
+	b.attrs.Name = append([]string(nil), names...)
+	return b
+}
+
+// AlternativeNames Sets the alternative names of the account holder.
+func (b *AccountBuilder) AlternativeNames(names ...string) *AccountBuilder {
+	b.attrs.AlternativeNames = append([]string(nil), names...)
+	return b
+}
+
+// SecondaryIdentification Sets the secondary identification, e.g. a building society roll number.
+func (b *AccountBuilder) SecondaryIdentification(id string) *AccountBuilder {
+	b.attrs.SecondaryIdentification = id
+	return b
+}
+
+// JointAccount Sets whether the account is held by several people.
+func (b *AccountBuilder) JointAccount(joint bool) *AccountBuilder {
+	b.attrs.JointAccount = Bool(joint)
+	return b
+}
+
+// AccountMatchingOptOut Sets whether the account opted out of account matching.
+func (b *AccountBuilder) AccountMatchingOptOut(optOut bool) *AccountBuilder {
+	b.attrs.AccountMatchingOptOut = Bool(optOut)
+	return b
+}
+
+// Switched Sets whether the account was switched to another bank.
+func (b *AccountBuilder) Switched(switched bool) *AccountBuilder {
+	b.attrs.Switched = Bool(switched)
+	return b
+}
+
+// PrivateIdentification Sets the identification of the individual holding the account.
+func (b *AccountBuilder) PrivateIdentification(id PrivateIdentification) *AccountBuilder {
+	b.attrs.PrivateIdentification = &id
+	return b
+}
+
+// OrganisationIdentification Sets the identification of the organisation holding the account.
+func (b *AccountBuilder) OrganisationIdentification(id OrganisationIdentification) *AccountBuilder {
+	b.attrs.OrganisationIdentification = &id
+	return b
+}
+
+// Build Returns the account, with the country presets filled in, once AccountData.Validate accepts it. The builder can
+// keep being used, later changes not affecting the returned account.
+func (b *AccountBuilder) Build() (AccountData, error) {
+	attrs := b.attrs
+	if attrs.Country != nil {
+		if rule, ok := countryRules[*attrs.Country]; ok && attrs.BankIDCode == "" {
+			attrs.BankIDCode = rule.bankIDCode
+		}
+		if attrs.BaseCurrency == "" {
+			attrs.BaseCurrency = countryCurrencies[*attrs.Country]
+		}
+	}
+
+	data := b.data
+	data.Attributes = &attrs
+	if err := data.Validate(); err != nil {
+		return AccountData{}, err
+	}
+
+	return data, nil
+}
+
+// newUUID Generates a random version 4 UUID.
+func newUUID() string {
+	u := make([]byte, 16)
+	randomBytes(u)
+	u[6] = u[6]&0x0f | 0x40
+	u[8] = u[8]&0x3f | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:])
+}
@@ -0,0 +1,99 @@
+//go:build !integration
+// +build !integration
+
+package organisation_api
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+)
+
+func TestAccountBuilder_Build(t *testing.T) {
+	testCases := []struct {
+		name       string
+		builder    *AccountBuilder
+		shouldFail bool
+	}{
+		{"Builds GB account with presets", NewAccountBuilder().
+			OrganisationID(mockAccountData.OrganisationID).
+			Country(CountryGB).
+			BankID("400302").
+			Bic("NWBKGB42").
+			AccountNumber("10000004").
+			Name("Kelvin", "Klein"), false},
+		{"Builds DE account with presets", NewAccountBuilder().
+			OrganisationID(mockAccountData.OrganisationID).
+			Country(CountryDE).
+			BankID("37040044").
+			AccountNumber("0532013").
+			Name("Kelvin"), false},
+		{"Fails with explicit wrong bank id code", NewAccountBuilder().
+			OrganisationID(mockAccountData.OrganisationID).
+			Country(CountryGB).
+			BankIDCode(BankIDCodeDEBLZ).
+			BankID("400302").
+			Bic("NWBKGB42").
+			Name("Kelvin"), true},
+		{"Fails without country", NewAccountBuilder().
+			OrganisationID(mockAccountData.OrganisationID).
+			Name("Kelvin"), true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := tc.builder.Build()
+			if (err != nil) != tc.shouldFail {
+				t.Fatal("Fail condition didn't match! Got", err, "should've been", tc.shouldFail)
+			}
+			if tc.shouldFail {
+				if !errors.Is(err, ErrValidation) {
+					t.Fatal("Expected validation error, got", err)
+				}
+				return
+			}
+
+			if data.Type != "accounts" || !uuidRegex.MatchString(data.ID) {
+				t.Fatal("Expected generated defaults, got", data)
+			}
+			if data.Attributes.BankIDCode == "" || data.Attributes.BaseCurrency == "" {
+				t.Fatal("Expected country presets, got", data.Attributes)
+			}
+		})
+	}
+}
+
+func TestAccountBuilder_BuildCopies(t *testing.T) {
+	b := NewAccountBuilder().
+		ID(mockAccountData.ID).
+		OrganisationID(mockAccountData.OrganisationID).
+		Country(CountryGB).
+		BankID("400302").
+		Bic("NWBKGB42").
+		BaseCurrency(CurrencyEUR).
+		Classification(ClassificationBusiness).
+		JointAccount(true).
+		Version(2).
+		Name("Kelvin")
+
+	data, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.ID != mockAccountData.ID || *data.Version != 2 || data.Attributes.BaseCurrency != CurrencyEUR ||
+		*data.Attributes.AccountClassification != ClassificationBusiness || !*data.Attributes.JointAccount {
+		t.Fatal("Expected the explicit values to be kept, got", data, data.Attributes)
+	}
+
+	b.Name("Somebody", "Else").Country(CountryDE)
+	if data.Attributes.Name[0] != "Kelvin" || *data.Attributes.Country != CountryGB {
+		t.Fatal("Expected the built account not to change with the builder, got", data.Attributes)
+	}
+}
+
+func TestNewUUID(t *testing.T) {
+	v4 := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	if a, b := newUUID(), newUUID(); !v4.MatchString(a) || a == b {
+		t.Fatal("Expected distinct version 4 UUIDs, got", a, b)
+	}
+}
@@ -0,0 +1,242 @@
+package organisation_api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+const defaultBulkConcurrency = 4
+
+// ErrFailureThreshold Cause of a bulk operation stopped after BulkOptions.MaxFailures failures.
+var ErrFailureThreshold = errors.New("organisation api: bulk failure threshold reached")
+
+// BulkOptions Settings of the bulk operations.
+type BulkOptions struct {
+	// Concurrency Number of operations running at the same time. Defaults to 4.
+	Concurrency int
+	// RatePerSecond Maximum number of operations started per second. Zero means unlimited.
+	RatePerSecond float64
+	// MaxFailures Stops starting new operations once this many have failed. Zero means never stopping.
+	MaxFailures int
+}
+
+// BulkResult Outcome of a single operation of a bulk, Index being the position of the item in the input.
+type BulkResult struct {
+	Index int
+	ID    string
+	Data  *AccountData
+	Err   error
+}
+
+// AccountVersion Identifies the version of an account to delete.
+type AccountVersion struct {
+	ID      string
+	Version int64
+}
+
+// BulkError Aggregated error of a bulk with failed operations, or stopped before processing every item. The failures
+// themselves are in the results.
+type BulkError struct {
+	Attempted int
+	Failed    int
+	// Cause Why the bulk stopped early: the context error or ErrFailureThreshold. Nil when every item was attempted.
+	Cause error
+}
+
+func (e *BulkError) Error() string {
+	msg := fmt.Sprintf("organisation api: %d of %d bulk operations failed", e.Failed, e.Attempted)
+	if e.Cause != nil {
+		msg += ", stopped early: " + e.Cause.Error()
+	}
+
+	return msg
+}
+
+// Unwrap Returns the cause of the early stop, if any.
+func (e *BulkError) Unwrap() error {
+	return e.Cause
+}
+
+// bulkJob Operation of a single item, run by the worker pool.
+type bulkJob struct {
+	index int
+	id    string
+	run   func(ctx context.Context) (*AccountData, error)
+}
+
+// bulkSource Returns the next job of the bulk, or false once there are none left or stop is closed. Called by a single
+// goroutine.
+type bulkSource func(stop <-chan struct{}) (bulkJob, bool)
+
+// BulkCreate Creates the accounts concurrently, see BulkCreateStream.
+func (s *accountsService) BulkCreate(ctx context.Context, accounts []AccountData, opts BulkOptions) ([]BulkResult, error) {
+	i := 0
+	return s.runBulk(ctx, opts, func(<-chan struct{}) (bulkJob, bool) {
+		if i == len(accounts) {
+			return bulkJob{}, false
+		}
+		i++
+		return s.createJob(accounts[i-1]), true
+	})
+}
+
+// BulkCreateStream Creates the accounts received from the channel until it is closed, through a bounded worker pool.
+// Returns the results of the attempted operations, ordered by their position in the input, and a *BulkError when any
+// failed or the bulk stopped early because of the context or the failure threshold.
+func (s *accountsService) BulkCreateStream(ctx context.Context, accounts <-chan AccountData, opts BulkOptions) ([]BulkResult, error) {
+	return s.runBulk(ctx, opts, func(stop <-chan struct{}) (bulkJob, bool) {
+		select {
+		case data, ok := <-accounts:
+			return s.createJob(data), ok
+		case <-stop:
+		case <-ctx.Done():
+		}
+		return bulkJob{}, false
+	})
+}
+
+// BulkDelete Deletes the account versions concurrently, see BulkDeleteStream.
+func (s *accountsService) BulkDelete(ctx context.Context, accounts []AccountVersion, opts BulkOptions) ([]BulkResult, error) {
+	i := 0
+	return s.runBulk(ctx, opts, func(<-chan struct{}) (bulkJob, bool) {
+		if i == len(accounts) {
+			return bulkJob{}, false
+		}
+		i++
+		return s.deleteJob(accounts[i-1]), true
+	})
+}
+
+// BulkDeleteStream Deletes the account versions received from the channel until it is closed, with the same
+// behaviour as BulkCreateStream.
+func (s *accountsService) BulkDeleteStream(ctx context.Context, accounts <-chan AccountVersion, opts BulkOptions) ([]BulkResult, error) {
+	return s.runBulk(ctx, opts, func(stop <-chan struct{}) (bulkJob, bool) {
+		select {
+		case account, ok := <-accounts:
+			return s.deleteJob(account), ok
+		case <-stop:
+		case <-ctx.Done():
+		}
+		return bulkJob{}, false
+	})
+}
+
+func (s *accountsService) createJob(data AccountData) bulkJob {
+	return bulkJob{id: data.ID, run: func(ctx context.Context) (*AccountData, error) {
+		return s.Create(ctx, data)
+	}}
+}
+
+func (s *accountsService) deleteJob(account AccountVersion) bulkJob {
+	return bulkJob{id: account.ID, run: func(ctx context.Context) (*AccountData, error) {
+		return nil, s.Delete(ctx, account.ID, account.Version)
+	}}
+}
+
+// runBulk Runs the jobs of the source through the worker pool. Once the failure threshold is reached or the context
+// done, the jobs not started yet are dropped, while the operations in flight are left to complete.
+func (s *accountsService) runBulk(ctx context.Context, opts BulkOptions, next bulkSource) ([]BulkResult, error) {
+	workers := opts.Concurrency
+	if workers <= 0 {
+		workers = defaultBulkConcurrency
+	}
+
+	var limiter *RateLimiter
+	if opts.RatePerSecond > 0 {
+		var err error
+		if limiter, err = NewRateLimiter(opts.RatePerSecond, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	stop := make(chan struct{})
+	var stopOnce sync.Once
+	stopped := func() bool {
+		select {
+		case <-stop:
+			return true
+		case <-ctx.Done():
+			return true
+		default:
+			return false
+		}
+	}
+
+	jobs := make(chan bulkJob)
+	go func() {
+		defer close(jobs)
+		for i := 0; ; i++ {
+			job, ok := next(stop)
+			if !ok {
+				return
+			}
+
+			job.index = i
+			select {
+			case jobs <- job:
+			case <-stop:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	results := make(chan BulkResult)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				if stopped() {
+					continue
+				}
+				if limiter != nil && (limiter.Wait(ctx) != nil || stopped()) {
+					continue
+				}
+
+				data, err := job.run(ctx)
+				results <- BulkResult{Index: job.index, ID: job.id, Data: data, Err: err}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var collected []BulkResult
+	bulkErr := &BulkError{}
+	for r := range results {
+		collected = append(collected, r)
+		bulkErr.Attempted++
+		if r.Err == nil {
+			continue
+		}
+
+		bulkErr.Failed++
+		if opts.MaxFailures > 0 && bulkErr.Failed >= opts.MaxFailures {
+			stopOnce.Do(func() {
+				bulkErr.Cause = ErrFailureThreshold
+				close(stop)
+			})
+		}
+	}
+
+	sort.Slice(collected, func(i, j int) bool {
+		return collected[i].Index < collected[j].Index
+	})
+
+	if bulkErr.Cause == nil && ctx.Err() != nil {
+		bulkErr.Cause = ctx.Err()
+	}
+	if bulkErr.Failed > 0 || bulkErr.Cause != nil {
+		return collected, bulkErr
+	}
+
+	return collected, nil
+}
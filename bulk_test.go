@@ -0,0 +1,128 @@
+//go:build !integration
+// +build !integration
+
+package organisation_api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/CG-SS/organisation-api/fakeapi"
+)
+
+func bulkAccounts(n int) []AccountData {
+	accounts := make([]AccountData, n)
+	for i := range accounts {
+		accounts[i] = mockAccountData
+		accounts[i].ID = fmt.Sprintf("123e4567-e89b-12d3-a456-%012d", i)
+		accounts[i].Version = nil
+	}
+
+	return accounts
+}
+
+func newBulkTestClient(t *testing.T, s *fakeapi.Server) AccountsService {
+	c, err := NewClient(WithBaseURL(s.RootURL().String()), WithHTTPClient(s.Client()), WithRetryPolicy(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c.Accounts()
+}
+
+func TestAccountsService_BulkCreateAndDelete(t *testing.T) {
+	s := fakeapi.NewServer(fakeapi.WithLatency(time.Millisecond))
+	defer s.Close()
+	accounts := newBulkTestClient(t, s)
+
+	results, err := accounts.BulkCreate(context.Background(), bulkAccounts(20), BulkOptions{Concurrency: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 20 || s.Len() != 20 {
+		t.Fatal("Expected 20 accounts created, got", len(results), "results and", s.Len(), "accounts")
+	}
+
+	var versions []AccountVersion
+	for i, r := range results {
+		if r.Index != i || r.Data == nil || r.Data.ID != r.ID {
+			t.Fatal("Results should be in input order, got", r)
+		}
+		versions = append(versions, AccountVersion{ID: r.ID, Version: *r.Data.Version})
+	}
+
+	if _, err := accounts.BulkDelete(context.Background(), versions, BulkOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if s.Len() != 0 {
+		t.Fatal("Expected every account to be deleted, got", s.Len())
+	}
+}
+
+func TestAccountsService_BulkFailures(t *testing.T) {
+	var requests int32
+	s := fakeapi.NewServer(fakeapi.WithFault(func(r *http.Request) (int, bool) {
+		n := atomic.AddInt32(&requests, 1)
+		return http.StatusServiceUnavailable, n%2 == 0
+	}))
+	defer s.Close()
+	accounts := newBulkTestClient(t, s)
+
+	results, err := accounts.BulkCreate(context.Background(), bulkAccounts(10), BulkOptions{Concurrency: 1})
+	var bulkErr *BulkError
+	if !errors.As(err, &bulkErr) || bulkErr.Failed != 5 || bulkErr.Attempted != 10 || bulkErr.Cause != nil {
+		t.Fatal("Expected 5 failures out of 10, got", err)
+	}
+	if !errors.Is(results[1].Err, ErrServer) || results[0].Err != nil {
+		t.Fatal("Expected per item errors, got", results[0].Err, results[1].Err)
+	}
+
+	results, err = accounts.BulkCreate(context.Background(), bulkAccounts(50), BulkOptions{Concurrency: 2, MaxFailures: 3})
+	if !errors.Is(err, ErrFailureThreshold) || len(results) >= 50 {
+		t.Fatal("Expected the bulk to stop at the failure threshold, got", err, "after", len(results), "results")
+	}
+}
+
+func TestAccountsService_BulkStreamCancellation(t *testing.T) {
+	s := fakeapi.NewServer()
+	defer s.Close()
+	accounts := newBulkTestClient(t, s)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	in := make(chan AccountData)
+	go func() {
+		for i, a := range bulkAccounts(3) {
+			in <- a
+			if i == 2 {
+				cancel()
+			}
+		}
+		// the channel is never closed, only the context stops the bulk
+	}()
+
+	results, err := accounts.BulkCreateStream(ctx, in, BulkOptions{Concurrency: 1})
+	if !errors.Is(err, context.Canceled) || len(results) > 3 {
+		t.Fatal("Expected the bulk to stop on cancellation, got", err, "after", len(results), "results")
+	}
+}
+
+func TestAccountsService_BulkRateLimit(t *testing.T) {
+	s := fakeapi.NewServer()
+	defer s.Close()
+	accounts := newBulkTestClient(t, s)
+
+	start := time.Now()
+	if _, err := accounts.BulkCreate(context.Background(), bulkAccounts(5), BulkOptions{Concurrency: 5, RatePerSecond: 100}); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Fatal("Expected 5 operations at 100/s to be spread over 40ms, took", elapsed)
+	}
+}
@@ -1,28 +1,114 @@
 package organisation_api
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"sync/atomic"
 	"time"
 )
 
+const (
+	defaultTimeout             = 10 * time.Second
+	defaultTLSHandshakeTimeout = 5 * time.Second
+)
+
 // OrganisationApiClient Struct for the API client. It uses http.Client as composition.
 type OrganisationApiClient struct {
 	*http.Client
 	ClientConfig *ClientConfig
+
+	stdLogger atomic.Value // *stdLogger adapting ClientConfig.DebugLog
+}
+
+// NewClient Builds a client configured by the given options. Without options it uses the API_URL env as the root URL,
+// falling back to the local default, a 10s timeout and DefaultRetryPolicy. Invalid options result in an error.
+func NewClient(opts ...Option) (*OrganisationApiClient, error) {
+	o := &clientOptions{
+		config: &ClientConfig{
+			RetryPolicy: DefaultRetryPolicy,
+		},
+	}
+
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+
+	if o.clientCredentials && o.config.Signer != nil {
+		// both authenticate through the Authorization header, the bearer token silently replacing the signature
+		return nil, errors.New("organisation api: WithSigner and WithClientCredentials can't be combined")
+	}
+
+	if o.config.RootUrl == nil {
+		u, err := rootUrlFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		o.config.RootUrl = u
+	}
+
+	var httpClient http.Client
+	if o.httpClient != nil {
+		// copied so that the timeout doesn't leak into the caller's client
+		httpClient = *o.httpClient
+	} else {
+		httpClient = http.Client{
+			Transport: &http.Transport{
+				Proxy:               http.ProxyFromEnvironment,
+				TLSHandshakeTimeout: defaultTLSHandshakeTimeout,
+			},
+		}
+	}
+	if o.timeout > 0 {
+		httpClient.Timeout = o.timeout
+	} else if o.httpClient == nil {
+		httpClient.Timeout = defaultTimeout
+	}
+
+	if len(o.middlewares) > 0 {
+		httpClient.Transport = Chain(httpClient.Transport, o.middlewares...)
+	}
+
+	if o.config.DefaultHeaders == nil {
+		o.config.DefaultHeaders = http.Header{}
+	}
+
+	return &OrganisationApiClient{
+		Client:       &httpClient,
+		ClientConfig: o.config,
+	}, nil
+}
+
+// MustNewClient Like NewClient, but panics on error. Meant for package level variables and tests.
+func MustNewClient(opts ...Option) *OrganisationApiClient {
+	c, err := NewClient(opts...)
+	if err != nil {
+		panic(fmt.Sprintf("organisation api: %v", err))
+	}
+
+	return c
 }
 
 // DefaultClient Default client with timeout defined.
+//
+// Deprecated: use NewClient, which reports an invalid API_URL when building the client. DefaultClient reads it once at
+// package initialisation and only reports it by failing every request, and its package-wide config can't be set
+// through options.
 var DefaultClient = &OrganisationApiClient{
 	Client: &http.Client{
-		Timeout: 10 * time.Second,
+		Timeout: defaultTimeout,
 		Transport: &http.Transport{
-			TLSHandshakeTimeout: 5 * time.Second,
+			TLSHandshakeTimeout: defaultTLSHandshakeTimeout,
 		},
 	},
 	ClientConfig: DefaultConfig,
 }
 
 // DebugClient Default client for debugging.
+//
+// Deprecated: use NewClient with WithLogger.
 var DebugClient = &OrganisationApiClient{
 	Client:       http.DefaultClient,
 	ClientConfig: DebugConfig,
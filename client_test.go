@@ -0,0 +1,142 @@
+//go:build !integration
+// +build !integration
+
+package organisation_api
+
+import (
+	"errors"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewClient(t *testing.T) {
+	httpClient := &http.Client{Timeout: time.Minute}
+
+	testCases := []struct {
+		name            string
+		opts            []Option
+		shouldFail      bool
+		expectedRootUrl string
+		expectedTimeout time.Duration
+	}{
+		{"Builds default client", nil, false, defaultRootUrl.String(), defaultTimeout},
+		{"Uses base URL", []Option{WithBaseURL("https://api.form3.tech/v1/organisation/")}, false, "https://api.form3.tech/v1/organisation/", defaultTimeout},
+		{"Keeps http client timeout", []Option{WithHTTPClient(httpClient)}, false, defaultRootUrl.String(), time.Minute},
+		{"Overrides http client timeout", []Option{WithTimeout(time.Second), WithHTTPClient(httpClient)}, false, defaultRootUrl.String(), time.Second},
+		{"Fails with relative base URL", []Option{WithBaseURL("/v1/organisation/")}, true, "", 0},
+		{"Fails with malformed base URL", []Option{WithBaseURL("http://[::1")}, true, "", 0},
+		{"Fails with negative timeout", []Option{WithTimeout(-time.Second)}, true, "", 0},
+		{"Fails with nil http client", []Option{WithHTTPClient(nil)}, true, "", 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := NewClient(tc.opts...)
+			if (err != nil) != tc.shouldFail {
+				t.Fatal("Fail condition didn't match! Got", err, "should've been", tc.shouldFail)
+			}
+			if err != nil {
+				return
+			}
+
+			if c.ClientConfig.RootUrl.String() != tc.expectedRootUrl {
+				t.Fatal("Expected root URL", tc.expectedRootUrl, "got", c.ClientConfig.RootUrl)
+			}
+			if c.Timeout != tc.expectedTimeout {
+				t.Fatal("Expected timeout", tc.expectedTimeout, "got", c.Timeout)
+			}
+		})
+	}
+
+	if httpClient.Timeout != time.Minute {
+		t.Fatal("Caller's http client was modified!")
+	}
+}
+
+func TestNewClient_createOptions(t *testing.T) {
+	c, err := NewClient(WithBaseURL("http://localhost:8080/v1/organisation/"), WithValidateBeforeCreate(true), WithResolveCreateConflicts(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.ClientConfig.ValidateBeforeCreate || !c.ClientConfig.ResolveCreateConflicts {
+		t.Fatal("Expected the create options to be set, got", c.ClientConfig)
+	}
+}
+
+func TestNewClient_invalidEnv(t *testing.T) {
+	previous, wasSet := os.LookupEnv("API_URL")
+	defer func() {
+		if wasSet {
+			os.Setenv("API_URL", previous)
+		} else {
+			os.Unsetenv("API_URL")
+		}
+	}()
+	os.Setenv("API_URL", "not a url")
+
+	if _, err := NewClient(); err == nil {
+		t.Fatal("Expected invalid API_URL error")
+	}
+	if _, err := NewClient(WithBaseURL("http://localhost:8080/v1/organisation/")); err != nil {
+		t.Fatal("Base URL option should take precedence over API_URL, got", err)
+	}
+}
+
+func TestDefaultConfig_invalidEnv(t *testing.T) {
+	previous := defaultRootUrlErr
+	defer func() { defaultRootUrlErr = previous }()
+	defaultRootUrlErr = errors.New("organisation api: invalid API_URL")
+
+	c := &OrganisationApiClient{
+		Client: &http.Client{Transport: roundTripAux(func(r *http.Request) (*http.Response, error) {
+			t.Fatal("Request was sent to", r.URL)
+			return nil, nil
+		})},
+		ClientConfig: DefaultConfig,
+	}
+
+	if _, err := c.FetchAccount(mockAccountData.ID); err != defaultRootUrlErr {
+		t.Fatal("Expected the API_URL error, got", err)
+	}
+}
+
+func TestNewClient_headers(t *testing.T) {
+	c, err := NewClient(
+		WithUserAgent("reconciliation/1.0"),
+		WithHeader("X-Tenant", "acme"),
+		WithDefaultHeaders(http.Header{"X-Tenant": []string{"globex"}}),
+		WithLogger(NewStdLogger(log.New(ioutil.Discard, "", 0), LevelDebug)),
+		WithRetryPolicy(nil),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Client.Transport = roundTripAux(
+		func(r *http.Request) (*http.Response, error) {
+			if r.Header.Get("User-Agent") != "reconciliation/1.0" {
+				t.Fatal("Wrong user agent! Got", r.Header.Get("User-Agent"))
+			}
+			if tenants := r.Header.Values("X-Tenant"); len(tenants) != 2 {
+				t.Fatal("Wrong default headers! Got", tenants)
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusNoContent,
+				Body:       ioutil.NopCloser(strings.NewReader("")),
+			}, nil
+		},
+	)
+
+	if _, err := c.DeleteAccount(mockAccountData.ID, 0); err != nil {
+		t.Fatal("Got client error", err)
+	}
+	if c.ClientConfig.Logger == nil || c.ClientConfig.RetryPolicy != nil {
+		t.Fatal("Logger and retry policy options weren't applied!")
+	}
+}
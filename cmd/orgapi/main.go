@@ -0,0 +1,440 @@
+// Command orgapi Command-line tool for operating on the organisation accounts of the Form3 API.
+//
+// Usage:
+//
+//	orgapi <create|fetch|delete|list|update> [flags]
+//
+// Every command accepts --api-url, --output json|table|yaml and --debug.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"strings"
+
+	organisation_api "github.com/CG-SS/organisation-api"
+)
+
+// Exit codes, one per error class, so scripts can react without parsing the output.
+const (
+	exitOK = iota
+	exitError
+	exitUsage
+	exitNotFound
+	exitConflict
+	exitValidation
+	exitUnauthorized
+	exitRateLimited
+	exitServer
+	exitNetwork
+)
+
+const usage = `Usage: orgapi <command> [flags]
+
+Commands:
+  create   Creates an account from a JSON file (--file) or from flags
+  fetch    Fetches an account by --id
+  delete   Deletes an account by --id and --version
+  list     Lists accounts, use --all to follow every page
+  update   Updates an account by --id from a JSON file (--file) or from flags
+
+Run 'orgapi <command> -h' for the flags of each command.
+`
+
+// globalFlags Flags shared by every command.
+type globalFlags struct {
+	apiUrl string
+	output string
+	debug  bool
+}
+
+func (g *globalFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&g.apiUrl, "api-url", os.Getenv("API_URL"), "root URL of the organisation API, defaults to the API_URL env")
+	fs.StringVar(&g.output, "output", "json", "output format: json, table or yaml")
+	fs.BoolVar(&g.debug, "debug", false, "logs the requests and responses to stderr")
+}
+
+func (g *globalFlags) client(stderr io.Writer, extra ...organisation_api.Option) (*organisation_api.OrganisationApiClient, error) {
+	opts := extra
+	if g.apiUrl != "" {
+		opts = append(opts, organisation_api.WithBaseURL(g.apiUrl))
+	}
+	if g.debug {
+		logger := organisation_api.NewStdLogger(log.New(stderr, "", log.Ldate|log.Ltime), organisation_api.LevelDebug)
+		opts = append(opts, organisation_api.WithLogger(logger))
+	}
+
+	return organisation_api.NewClient(opts...)
+}
+
+// accountFlags Flags for building an account without a JSON file.
+type accountFlags struct {
+	file           string
+	id             string
+	organisationID string
+	country        string
+	bankID         string
+	bankIDCode     string
+	bic            string
+	accountNumber  string
+	iban           string
+	currency       string
+	classification string
+	names          string
+}
+
+func (a *accountFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&a.file, "file", "", "JSON file with the account, either bare or wrapped in {\"data\": ...}")
+	fs.StringVar(&a.id, "id", "", "account ID")
+	fs.StringVar(&a.organisationID, "organisation-id", "", "organisation ID")
+	fs.StringVar(&a.country, "country", "", "ISO 3166-1 country code")
+	fs.StringVar(&a.bankID, "bank-id", "", "bank ID")
+	fs.StringVar(&a.bankIDCode, "bank-id-code", "", "bank ID code, e.g. GBDSC")
+	fs.StringVar(&a.bic, "bic", "", "BIC")
+	fs.StringVar(&a.accountNumber, "account-number", "", "account number")
+	fs.StringVar(&a.iban, "iban", "", "IBAN")
+	fs.StringVar(&a.currency, "currency", "", "ISO 4217 base currency")
+	fs.StringVar(&a.classification, "classification", "", "Personal or Business")
+	fs.StringVar(&a.names, "name", "", "comma separated account holder names")
+}
+
+// apply Sets the attributes given by flags on the account, leaving the others untouched.
+func (a *accountFlags) apply(data *organisation_api.AccountData) {
+	if a.id != "" {
+		data.ID = a.id
+	}
+	if a.organisationID != "" {
+		data.OrganisationID = a.organisationID
+	}
+	if data.Attributes == nil {
+		data.Attributes = &organisation_api.AccountAttributes{}
+	}
+
+	attrs := data.Attributes
+	if a.country != "" {
+		attrs.Country = organisation_api.Country(a.country).Ptr()
+	}
+	if a.classification != "" {
+		attrs.AccountClassification = organisation_api.AccountClassification(a.classification).Ptr()
+	}
+	if a.bankIDCode != "" {
+		attrs.BankIDCode = organisation_api.BankIDCode(a.bankIDCode)
+	}
+	if a.currency != "" {
+		attrs.BaseCurrency = organisation_api.Currency(a.currency)
+	}
+	if a.names != "" {
+		attrs.Name = strings.Split(a.names, ",")
+	}
+
+	for _, f := range []struct {
+		value string
+		field *string
+	}{
+		{a.bankID, &attrs.BankID},
+		{a.bic, &attrs.Bic},
+		{a.accountNumber, &attrs.AccountNumber},
+		{a.iban, &attrs.Iban},
+	} {
+		if f.value != "" {
+			*f.field = f.value
+		}
+	}
+}
+
+func (a *accountFlags) load() (organisation_api.AccountData, error) {
+	data := organisation_api.AccountData{}
+	if a.file != "" {
+		b, err := os.ReadFile(a.file)
+		if err != nil {
+			return data, err
+		}
+
+		holder := struct {
+			Data *organisation_api.AccountData `json:"data"`
+		}{}
+		if err := json.Unmarshal(b, &holder); err != nil {
+			return data, err
+		}
+		if holder.Data != nil {
+			data = *holder.Data
+		} else if err := json.Unmarshal(b, &data); err != nil {
+			return data, err
+		}
+	}
+
+	a.apply(&data)
+	if data.Type == "" {
+		data.Type = "accounts"
+	}
+
+	return data, nil
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+
+	commands := map[string]func([]string, io.Writer, io.Writer) int{
+		"create": runCreate,
+		"fetch":  runFetch,
+		"delete": runDelete,
+		"list":   runList,
+		"update": runUpdate,
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		if args[0] != "-h" && args[0] != "--help" && args[0] != "help" {
+			fmt.Fprintln(stderr, "Unknown command", args[0])
+		}
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+
+	return cmd(args[1:], stdout, stderr)
+}
+
+func newFlagSet(name string, stderr io.Writer, g *globalFlags) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	g.register(fs)
+
+	return fs
+}
+
+func runCreate(args []string, stdout io.Writer, stderr io.Writer) int {
+	g := &globalFlags{}
+	a := &accountFlags{}
+	fs := newFlagSet("create", stderr, g)
+	a.register(fs)
+	validate := fs.Bool("validate", true, "validates the account before sending it")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	data, err := a.load()
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	c, err := g.client(stderr, organisation_api.WithValidateBeforeCreate(*validate))
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	resp, err := c.CreateAccount(data)
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	return printAccount(stdout, stderr, g.output, resp.Data)
+}
+
+func runFetch(args []string, stdout io.Writer, stderr io.Writer) int {
+	g := &globalFlags{}
+	fs := newFlagSet("fetch", stderr, g)
+	id := fs.String("id", "", "account ID")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *id == "" && fs.NArg() == 1 {
+		*id = fs.Arg(0)
+	}
+	if *id == "" {
+		fmt.Fprintln(stderr, "Missing --id")
+		return exitUsage
+	}
+
+	c, err := g.client(stderr)
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	resp, err := c.FetchAccount(*id)
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	return printAccount(stdout, stderr, g.output, resp.Data)
+}
+
+func runDelete(args []string, stdout io.Writer, stderr io.Writer) int {
+	g := &globalFlags{}
+	fs := newFlagSet("delete", stderr, g)
+	id := fs.String("id", "", "account ID")
+	version := fs.Int64("version", -1, "current version of the account, fetched when omitted")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *id == "" {
+		fmt.Fprintln(stderr, "Missing --id")
+		return exitUsage
+	}
+
+	c, err := g.client(stderr)
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	if *version < 0 {
+		resp, err := c.FetchAccount(*id)
+		if err != nil {
+			return fail(stderr, err)
+		}
+		if resp.Data.Version != nil {
+			*version = *resp.Data.Version
+		}
+	}
+
+	if _, err := c.DeleteAccount(*id, *version); err != nil {
+		return fail(stderr, err)
+	}
+
+	return exitOK
+}
+
+func runList(args []string, stdout io.Writer, stderr io.Writer) int {
+	g := &globalFlags{}
+	fs := newFlagSet("list", stderr, g)
+	opts := organisation_api.ListOptions{}
+	fs.IntVar(&opts.PageNumber, "page-number", 0, "page to list")
+	fs.IntVar(&opts.PageSize, "page-size", 0, "accounts per page")
+	all := fs.Bool("all", false, "follows the next links until every account is listed")
+	filters := []struct {
+		name   string
+		values *[]string
+	}{
+		{"bank-id", &opts.Filter.BankID},
+		{"bank-id-code", &opts.Filter.BankIDCode},
+		{"account-number", &opts.Filter.AccountNumber},
+		{"iban", &opts.Filter.Iban},
+		{"country", &opts.Filter.Country},
+		{"customer-id", &opts.Filter.CustomerID},
+	}
+	for _, f := range filters {
+		values := f.values
+		fs.Func(f.name, "filters by "+f.name+", comma separated", func(s string) error {
+			*values = append(*values, strings.Split(s, ",")...)
+			return nil
+		})
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	c, err := g.client(stderr)
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	accounts := []*organisation_api.AccountData{}
+	if *all {
+		it := c.IterateAccountsWithContext(opts, context.Background())
+		for it.Next() {
+			accounts = append(accounts, it.Account())
+		}
+		if it.Err() != nil {
+			return fail(stderr, it.Err())
+		}
+	} else {
+		resp, err := c.ListAccounts(opts)
+		if err != nil {
+			return fail(stderr, err)
+		}
+		for i := range resp.Data {
+			accounts = append(accounts, &resp.Data[i])
+		}
+	}
+
+	return printAccountList(stdout, stderr, g.output, accounts)
+}
+
+func runUpdate(args []string, stdout io.Writer, stderr io.Writer) int {
+	g := &globalFlags{}
+	a := &accountFlags{}
+	fs := newFlagSet("update", stderr, g)
+	a.register(fs)
+	attempts := fs.Int("attempts", 3, "attempts when the account changes concurrently")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if a.id == "" {
+		fmt.Fprintln(stderr, "Missing --id")
+		return exitUsage
+	}
+
+	var fromFile *organisation_api.AccountData
+	if a.file != "" {
+		data, err := a.load()
+		if err != nil {
+			return fail(stderr, err)
+		}
+		fromFile = &data
+	}
+
+	c, err := g.client(stderr)
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	resp, err := c.UpdateAccountWithRetry(a.id, func(data *organisation_api.AccountData) error {
+		// only the attributes are replaced, the identifiers of the stored account are kept
+		if fromFile != nil && fromFile.Attributes != nil {
+			attributes := *fromFile.Attributes
+			data.Attributes = &attributes
+		}
+		a.apply(data)
+		return nil
+	}, *attempts)
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	return printAccount(stdout, stderr, g.output, resp.Data)
+}
+
+// fail Prints the error and maps it to the exit code of its class.
+func fail(stderr io.Writer, err error) int {
+	fmt.Fprintln(stderr, "Error:", err)
+
+	return exitCode(err)
+}
+
+func exitCode(err error) int {
+	var netErr net.Error
+
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, organisation_api.ErrNotFound):
+		return exitNotFound
+	case errors.Is(err, organisation_api.ErrConflict):
+		return exitConflict
+	case errors.Is(err, organisation_api.ErrValidation):
+		return exitValidation
+	case errors.Is(err, organisation_api.ErrUnauthorized):
+		return exitUnauthorized
+	case errors.Is(err, organisation_api.ErrRateLimited):
+		return exitRateLimited
+	case errors.Is(err, organisation_api.ErrServer):
+		return exitServer
+	case errors.As(err, &netErr), errors.Is(err, context.DeadlineExceeded):
+		return exitNetwork
+	}
+
+	return exitError
+}
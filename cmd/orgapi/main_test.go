@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	organisation_api "github.com/CG-SS/organisation-api"
+	"github.com/CG-SS/organisation-api/fakeapi"
+)
+
+const testAccountID = "123e4567-e89b-12d3-a456-426614174129"
+
+func runCmd(t *testing.T, args ...string) (int, string, string) {
+	t.Helper()
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := run(args, stdout, stderr)
+
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun(t *testing.T) {
+	s := fakeapi.NewServer()
+	defer s.Close()
+	apiUrl := "--api-url=" + s.RootURL().String()
+
+	code, out, errOut := runCmd(t, "create", apiUrl,
+		"--id", testAccountID,
+		"--organisation-id", "123e4567-e89b-12d3-a456-426614174111",
+		"--country", "GB",
+		"--bank-id", "400302",
+		"--bank-id-code", "GBDSC",
+		"--bic", "NWBKGB42",
+		"--name", "Kelvin,Klein",
+	)
+	if code != exitOK {
+		t.Fatal("Create failed with code", code, errOut)
+	}
+
+	created := organisation_api.AccountData{}
+	if err := json.Unmarshal([]byte(out), &created); err != nil {
+		t.Fatal(err)
+	}
+	if created.ID != testAccountID || created.Attributes.Name[1] != "Klein" {
+		t.Fatal("Wrong account created! Got", out)
+	}
+
+	code, _, errOut = runCmd(t, "update", apiUrl, "--id", testAccountID, "--name", "Calvin,Klein")
+	if code != exitOK {
+		t.Fatal("Update failed with code", code, errOut)
+	}
+
+	code, out, errOut = runCmd(t, "fetch", apiUrl, "--output", "yaml", testAccountID)
+	if code != exitOK {
+		t.Fatal("Fetch failed with code", code, errOut)
+	}
+	if !strings.Contains(out, "version: 1\n") || !strings.Contains(out, "  - \"Calvin\"\n") {
+		t.Fatal("Wrong yaml output! Got", out)
+	}
+
+	code, out, errOut = runCmd(t, "list", apiUrl, "--output", "table", "--all", "--country", "GB")
+	if code != exitOK {
+		t.Fatal("List failed with code", code, errOut)
+	}
+	if lines := strings.Split(strings.TrimSpace(out), "\n"); len(lines) != 2 || !strings.HasPrefix(lines[1], testAccountID) {
+		t.Fatal("Wrong table output! Got", out)
+	}
+
+	code, _, errOut = runCmd(t, "delete", apiUrl, "--id", testAccountID)
+	if code != exitOK {
+		t.Fatal("Delete failed with code", code, errOut)
+	}
+}
+
+func TestRun_exitCodes(t *testing.T) {
+	s := fakeapi.NewServer()
+	defer s.Close()
+	apiUrl := "--api-url=" + s.RootURL().String()
+
+	testCases := []struct {
+		name     string
+		args     []string
+		expected int
+	}{
+		{"Fails without command", nil, exitUsage},
+		{"Fails with unknown command", []string{"rename"}, exitUsage},
+		{"Fails with unknown output", []string{"list", apiUrl, "--output", "xml"}, exitUsage},
+		{"Fails fetching missing account", []string{"fetch", apiUrl, testAccountID}, exitNotFound},
+		{"Fails creating invalid account", []string{"create", apiUrl, "--country", "GB"}, exitValidation},
+		{"Fails creating invalid account server side", []string{"create", apiUrl, "--validate=false"}, exitValidation},
+		{"Fails without server", []string{"fetch", "--api-url=http://127.0.0.1:1/v1/organisation/", testAccountID}, exitNetwork},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			code, _, errOut := runCmd(t, tc.args...)
+			if code != tc.expected {
+				t.Fatal("Expected exit code", tc.expected, "got", code, errOut)
+			}
+		})
+	}
+}
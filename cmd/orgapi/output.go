@@ -0,0 +1,175 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	organisation_api "github.com/CG-SS/organisation-api"
+)
+
+// printAccount Prints a single account in the given format.
+func printAccount(stdout io.Writer, stderr io.Writer, format string, account *organisation_api.AccountData) int {
+	return printOutput(stdout, stderr, format, account, []*organisation_api.AccountData{account})
+}
+
+// printAccountList Prints the accounts in the given format, as a list even when there is only one.
+func printAccountList(stdout io.Writer, stderr io.Writer, format string, accounts []*organisation_api.AccountData) int {
+	return printOutput(stdout, stderr, format, accounts, accounts)
+}
+
+func printOutput(stdout io.Writer, stderr io.Writer, format string, v interface{}, accounts []*organisation_api.AccountData) int {
+	var err error
+	switch format {
+	case "json":
+		err = printJson(stdout, v)
+	case "yaml":
+		err = printYaml(stdout, v)
+	case "table":
+		err = printTable(stdout, accounts)
+	default:
+		fmt.Fprintln(stderr, "Unknown output format", format)
+		return exitUsage
+	}
+
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	return exitOK
+}
+
+func printJson(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(v)
+}
+
+func printTable(w io.Writer, accounts []*organisation_api.AccountData) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tVERSION\tCOUNTRY\tBANK ID\tACCOUNT NUMBER\tIBAN\tNAME")
+
+	for _, a := range accounts {
+		version := ""
+		if a.Version != nil {
+			version = strconv.FormatInt(*a.Version, 10)
+		}
+
+		attrs := a.Attributes
+		if attrs == nil {
+			attrs = &organisation_api.AccountAttributes{}
+		}
+		var country organisation_api.Country
+		if attrs.Country != nil {
+			country = *attrs.Country
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", a.ID, version, country, attrs.BankID, attrs.AccountNumber,
+			attrs.Iban, strings.Join(attrs.Name, " "))
+	}
+
+	return tw.Flush()
+}
+
+// printYaml Prints the value as YAML, going through its JSON form so that the JSON field names are kept.
+func printYaml(w io.Writer, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var generic interface{}
+	if err := dec.Decode(&generic); err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	writeYaml(&sb, generic, 0)
+	_, err = io.WriteString(w, sb.String())
+
+	return err
+}
+
+func writeYaml(sb *strings.Builder, v interface{}, indent int) {
+	pad := strings.Repeat("  ", indent)
+
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if len(t) == 0 {
+			sb.WriteString(pad + "{}\n")
+			return
+		}
+
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			if isYamlScalar(t[k]) {
+				sb.WriteString(pad + k + ": " + yamlScalar(t[k]) + "\n")
+			} else {
+				sb.WriteString(pad + k + ":\n")
+				writeYaml(sb, t[k], indent+1)
+			}
+		}
+	case []interface{}:
+		if len(t) == 0 {
+			sb.WriteString(pad + "[]\n")
+			return
+		}
+
+		for _, item := range t {
+			if isYamlScalar(item) {
+				sb.WriteString(pad + "- " + yamlScalar(item) + "\n")
+				continue
+			}
+
+			// nested collections start on the line after the dash
+			sb.WriteString(pad + "-\n")
+			writeYaml(sb, item, indent+1)
+		}
+	default:
+		sb.WriteString(pad + yamlScalar(t) + "\n")
+	}
+}
+
+func isYamlScalar(v interface{}) bool {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		return len(t) == 0
+	case []interface{}:
+		return len(t) == 0
+	}
+
+	return true
+}
+
+func yamlScalar(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return strconv.Quote(t)
+	case bool:
+		return strconv.FormatBool(t)
+	case json.Number:
+		return t.String()
+	case map[string]interface{}:
+		return "{}"
+	case []interface{}:
+		return "[]"
+	}
+
+	return fmt.Sprint(v)
+}
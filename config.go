@@ -1,48 +1,106 @@
 package organisation_api
 
 import (
+	"fmt"
 	"log"
+	"net/http"
 	"net/url"
 	"os"
 )
 
 // ClientConfig Struct representing the client config.
 type ClientConfig struct {
-	RootUrl        *url.URL
+	RootUrl *url.URL
+	// Logger Receives the leveled events of the client. Takes precedence over DebugLog.
+	Logger Logger
+	// DebugLog Legacy logger, adapted with NewStdLogger. Debug events, such as the bodies received, are only logged
+	// when IsDebugEnabled is set.
 	DebugLog       *log.Logger
 	IsDebugEnabled bool
+	// Redactor Masks personal data out of the logged payloads and URLs. Defaults to DefaultRedactor.
+	Redactor    *Redactor
+	RetryPolicy *RetryPolicy
+	// ResolveCreateConflicts Makes CreateAccount treat a 409 as a success when an identical account already exists.
+	ResolveCreateConflicts bool
+	// ValidateBeforeCreate Makes CreateAccount run AccountData.Validate before sending the request.
+	ValidateBeforeCreate bool
+	// UserAgent User-Agent header of every request, when set.
+	UserAgent string
+	// DefaultHeaders Headers added to every request.
+	DefaultHeaders http.Header
+	// Signer Signs every request when set, see HTTPSigner.
+	Signer RequestSigner
+	// Tracer Traces every operation when set, propagating the spans in the traceparent header.
+	Tracer Tracer
+	// Metrics Records the metrics of every operation when set.
+	Metrics *Metrics
+	// RateLimiter Limits the requests sent when set. It can be shared by several clients.
+	RateLimiter *RateLimiter
+	// CircuitBreaker Fails the requests fast with ErrCircuitOpen while the API keeps failing, when set. It can be shared
+	// by several clients.
+	CircuitBreaker *CircuitBreaker
+	// RequestID Generates the X-Request-Id header of every operation's request without one, when set. It is set once
+	// per request, so its retries, log events and spans share it.
+	RequestID func() string
 }
 
-var defaultRootUrl = func() *url.URL {
+const fallbackRootUrl = "http://localhost:8080/v1/organisation/"
+
+// rootUrlFromEnv Reads the root URL from the API_URL env, falling back to the local default when it is unset.
+func rootUrlFromEnv() (*url.URL, error) {
 	s := os.Getenv("API_URL")
+	if s == "" {
+		s = fallbackRootUrl
+	}
+
+	u, err := parseRootUrl(s)
+	if err != nil {
+		return nil, fmt.Errorf("organisation api: invalid API_URL: %w", err)
+	}
 
-	if s != "" {
-		d, err := url.Parse(s)
-		if err != nil {
-			panic(err)
-		}
+	return u, nil
+}
 
-		return d
+func parseRootUrl(s string) (*url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("organisation api: root URL %q must be an absolute http(s) URL", s)
 	}
 
-	d, err := url.Parse("http://localhost:8080/v1/organisation/")
+	return u, nil
+}
+
+// defaultRootUrl Root URL of the deprecated global configs. A malformed API_URL doesn't crash the importing binary at
+// init: the URL is set to the local default and defaultRootUrlErr is returned by every request made with it, instead
+// of sending them to the wrong host.
+var defaultRootUrl, defaultRootUrlErr = func() (*url.URL, error) {
+	u, err := rootUrlFromEnv()
 	if err != nil {
-		panic(err)
+		u, _ = url.Parse(fallbackRootUrl)
 	}
 
-	return d
+	return u, err
 }()
 
-// DefaultConfig Default config with no logging or debugging.
+// DefaultConfig Default config with no logging or debugging, retrying transient failures.
+//
+// Deprecated: use NewClient, which builds a config per client.
 var DefaultConfig = &ClientConfig{
 	RootUrl:        defaultRootUrl,
 	DebugLog:       nil,
 	IsDebugEnabled: false,
+	RetryPolicy:    DefaultRetryPolicy,
 }
 
 // DebugConfig Config meant for debugging capabilities, with logging.
+//
+// Deprecated: use NewClient with WithLogger.
 var DebugConfig = &ClientConfig{
 	RootUrl:        defaultRootUrl,
 	DebugLog:       log.New(os.Stdout, "DEBUG\t", log.Ldate|log.Ltime),
 	IsDebugEnabled: true,
+	RetryPolicy:    DefaultRetryPolicy,
 }
@@ -0,0 +1,230 @@
+package organisation_api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// AccountStatus Status of an account.
+type AccountStatus string
+
+const (
+	AccountStatusPending   AccountStatus = "pending"
+	AccountStatusConfirmed AccountStatus = "confirmed"
+	AccountStatusClosed    AccountStatus = "closed"
+	AccountStatusFailed    AccountStatus = "failed"
+)
+
+// AccountClassification Classification of an account.
+type AccountClassification string
+
+const (
+	ClassificationPersonal AccountClassification = "Personal"
+	ClassificationBusiness AccountClassification = "Business"
+)
+
+// BankIDCode Type of the bank_id of an account, depending on its country.
+type BankIDCode string
+
+const (
+	BankIDCodeAUBSB BankIDCode = "AUBSB"
+	BankIDCodeBE    BankIDCode = "BE"
+	BankIDCodeCACPA BankIDCode = "CACPA"
+	BankIDCodeCHBCC BankIDCode = "CHBCC"
+	BankIDCodeDEBLZ BankIDCode = "DEBLZ"
+	BankIDCodeESNCC BankIDCode = "ESNCC"
+	BankIDCodeFR    BankIDCode = "FR"
+	BankIDCodeGBDSC BankIDCode = "GBDSC"
+	BankIDCodeGRBIC BankIDCode = "GRBIC"
+	BankIDCodeHKNCC BankIDCode = "HKNCC"
+	BankIDCodeITNCC BankIDCode = "ITNCC"
+	BankIDCodeLULUX BankIDCode = "LULUX"
+	BankIDCodePLKNR BankIDCode = "PLKNR"
+	BankIDCodePTNCC BankIDCode = "PTNCC"
+	BankIDCodeUSABA BankIDCode = "USABA"
+)
+
+// Country ISO 3166-1 alpha-2 country code.
+type Country string
+
+// Countries with specific Form3 rules for their bank and account identifiers. Any other ISO 3166-1 code is known too.
+const (
+	CountryAU Country = "AU"
+	CountryBE Country = "BE"
+	CountryCA Country = "CA"
+	CountryCH Country = "CH"
+	CountryDE Country = "DE"
+	CountryES Country = "ES"
+	CountryFR Country = "FR"
+	CountryGB Country = "GB"
+	CountryGR Country = "GR"
+	CountryHK Country = "HK"
+	CountryIT Country = "IT"
+	CountryLU Country = "LU"
+	CountryNL Country = "NL"
+	CountryPL Country = "PL"
+	CountryPT Country = "PT"
+	CountryUS Country = "US"
+)
+
+// Currency ISO 4217 currency code.
+type Currency string
+
+// Currencies of the countries with specific Form3 rules. Any other ISO 4217 code is known too.
+const (
+	CurrencyAUD Currency = "AUD"
+	CurrencyCAD Currency = "CAD"
+	CurrencyCHF Currency = "CHF"
+	CurrencyEUR Currency = "EUR"
+	CurrencyGBP Currency = "GBP"
+	CurrencyHKD Currency = "HKD"
+	CurrencyPLN Currency = "PLN"
+	CurrencyUSD Currency = "USD"
+)
+
+var (
+	knownStatuses        = codeSet("pending confirmed closed failed")
+	knownClassifications = codeSet("Personal Business")
+	knownBankIDCodes     = codeSet("AUBSB BE CACPA CHBCC DEBLZ ESNCC FR GBDSC GRBIC HKNCC ITNCC LULUX PLKNR PTNCC USABA")
+
+	knownCountries = codeSet(`
+		AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ BA BB BD BE BF BG BH BI BJ BL BM BN BO BQ BR BS BT BV BW BY BZ
+		CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV CW CX CY CZ DE DJ DK DM DO DZ EC EE EG EH ER ES ET FI FJ FK FM FO
+		FR GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY HK HM HN HR HT HU ID IE IL IM IN IO IQ IR IS IT JE
+		JM JO JP KE KG KH KI KM KN KP KR KW KY KZ LA LB LC LI LK LR LS LT LU LV LY MA MC MD ME MF MG MH MK ML MM MN MO
+		MP MQ MR MS MT MU MV MW MX MY MZ NA NC NE NF NG NI NL NO NP NR NU NZ OM PA PE PF PG PH PK PL PM PN PR PS PT PW
+		PY QA RE RO RS RU RW SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS ST SV SX SY SZ TC TD TF TG TH TJ TK TL TM
+		TN TO TR TT TV TW TZ UA UG UM US UY UZ VA VC VE VG VI VN VU WF WS YE YT ZA ZM ZW`)
+
+	knownCurrencies = codeSet(`
+		AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND BOB BRL BSD BTN BWP BYN BZD CAD CDF CHF
+		CLP CNY COP CRC CUP CVE CZK DJF DKK DOP DZD EGP ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD GNF GTQ GYD HKD HNL HTG
+		HUF IDR ILS INR IQD IRR ISK JMD JOD JPY KES KGS KHR KMF KPW KRW KWD KYD KZT LAK LBP LKR LRD LSL LYD MAD MDL MGA
+		MKD MMK MNT MOP MRU MUR MVR MWK MXN MYR MZN NAD NGN NIO NOK NPR NZD OMR PAB PEN PGK PHP PKR PLN PYG QAR RON RSD
+		RUB RWF SAR SBD SCR SDG SEK SGD SHP SLE SOS SRD SSP STN SVC SYP SZL THB TJS TMT TND TOP TRY TTD TWD TZS UAH UGX
+		USD UYU UZS VES VND VUV WST XAF XCD XOF XPF YER ZAR ZMW ZWL`)
+)
+
+func codeSet(codes string) map[string]bool {
+	set := map[string]bool{}
+	for _, code := range strings.Fields(codes) {
+		set[code] = true
+	}
+
+	return set
+}
+
+// IsKnown Reports whether the status is one of the AccountStatus constants.
+func (s AccountStatus) IsKnown() bool { return knownStatuses[string(s)] }
+
+// Ptr Returns a pointer to a copy of the status.
+func (s AccountStatus) Ptr() *AccountStatus { return &s }
+
+// MarshalJSON Encodes the status as is, known or not.
+func (s AccountStatus) MarshalJSON() ([]byte, error) { return json.Marshal(string(s)) }
+
+// UnmarshalJSON Decodes any string, see AccountAttributes.UnknownValues.
+func (s *AccountStatus) UnmarshalJSON(b []byte) error {
+	return unmarshalEnum(b, "account status", (*string)(s))
+}
+
+// IsKnown Reports whether the classification is Personal or Business.
+func (c AccountClassification) IsKnown() bool { return knownClassifications[string(c)] }
+
+// Ptr Returns a pointer to a copy of the classification.
+func (c AccountClassification) Ptr() *AccountClassification { return &c }
+
+// MarshalJSON Encodes the classification as is, known or not.
+func (c AccountClassification) MarshalJSON() ([]byte, error) { return json.Marshal(string(c)) }
+
+// UnmarshalJSON Decodes any string, see AccountAttributes.UnknownValues.
+func (c *AccountClassification) UnmarshalJSON(b []byte) error {
+	return unmarshalEnum(b, "account classification", (*string)(c))
+}
+
+// IsKnown Reports whether the code is one of the BankIDCode constants.
+func (c BankIDCode) IsKnown() bool { return knownBankIDCodes[string(c)] }
+
+// MarshalJSON Encodes the code as is, known or not.
+func (c BankIDCode) MarshalJSON() ([]byte, error) { return json.Marshal(string(c)) }
+
+// UnmarshalJSON Decodes any string, see AccountAttributes.UnknownValues.
+func (c *BankIDCode) UnmarshalJSON(b []byte) error {
+	return unmarshalEnum(b, "bank id code", (*string)(c))
+}
+
+// IsKnown Reports whether the country is an ISO 3166-1 alpha-2 code.
+func (c Country) IsKnown() bool { return knownCountries[string(c)] }
+
+// Ptr Returns a pointer to a copy of the country.
+func (c Country) Ptr() *Country { return &c }
+
+// MarshalJSON Encodes the country as is, known or not.
+func (c Country) MarshalJSON() ([]byte, error) { return json.Marshal(string(c)) }
+
+// UnmarshalJSON Decodes any string, see AccountAttributes.UnknownValues.
+func (c *Country) UnmarshalJSON(b []byte) error {
+	return unmarshalEnum(b, "country", (*string)(c))
+}
+
+// IsKnown Reports whether the currency is an active ISO 4217 code.
+func (c Currency) IsKnown() bool { return knownCurrencies[string(c)] }
+
+// MarshalJSON Encodes the currency as is, known or not.
+func (c Currency) MarshalJSON() ([]byte, error) { return json.Marshal(string(c)) }
+
+// UnmarshalJSON Decodes any string, see AccountAttributes.UnknownValues.
+func (c *Currency) UnmarshalJSON(b []byte) error {
+	return unmarshalEnum(b, "currency", (*string)(c))
+}
+
+// unmarshalEnum Decodes a JSON string into value, tolerating values unknown to the client so that accounts using
+// codes added by the server still decode.
+func unmarshalEnum(b []byte, name string, value *string) error {
+	if string(b) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(b, value); err != nil {
+		return fmt.Errorf("organisation api: %s must be a string: %w", name, err)
+	}
+
+	return nil
+}
+
+// Bool Returns a pointer to the value, for the optional flags of the attributes.
+func Bool(v bool) *bool {
+	return &v
+}
+
+// Int64 Returns a pointer to the value, e.g. for AccountData.Version.
+func Int64(v int64) *int64 {
+	return &v
+}
+
+// UnknownValues Returns an error for every status, classification, bank_id_code, country and base_currency value
+// unknown to the client. Such values are accepted when decoding, as the server may know more of them.
+func (a *AccountAttributes) UnknownValues() []FieldError {
+	var errs []FieldError
+	add := func(field string, value string) {
+		errs = append(errs, FieldError{Field: "attributes." + field, Message: fmt.Sprintf("unknown value %q", value)})
+	}
+
+	if a.AccountClassification != nil && !a.AccountClassification.IsKnown() {
+		add("account_classification", string(*a.AccountClassification))
+	}
+	if a.BankIDCode != "" && !a.BankIDCode.IsKnown() {
+		add("bank_id_code", string(a.BankIDCode))
+	}
+	if a.BaseCurrency != "" && !a.BaseCurrency.IsKnown() {
+		add("base_currency", string(a.BaseCurrency))
+	}
+	if a.Country != nil && !a.Country.IsKnown() {
+		add("country", string(*a.Country))
+	}
+	if a.Status != nil && !a.Status.IsKnown() {
+		add("status", string(*a.Status))
+	}
+
+	return errs
+}
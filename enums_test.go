@@ -0,0 +1,93 @@
+//go:build !integration
+// +build !integration
+
+package organisation_api
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestAccountAttributes_EnumJSON(t *testing.T) {
+	testCases := []struct {
+		name          string
+		json          string
+		unknownFields []string
+		shouldFail    bool
+	}{
+		{"Decodes known values", `{"account_classification":"Business","bank_id_code":"DEBLZ","base_currency":"EUR","country":"DE","status":"confirmed"}`, nil, false},
+		{"Tolerates unknown values", `{"account_classification":"Personnal","bank_id_code":"XXNCC","base_currency":"XYZ","country":"ZZ","status":"archived"}`,
+			[]string{"attributes.account_classification", "attributes.bank_id_code", "attributes.base_currency", "attributes.country", "attributes.status"}, false},
+		{"Decodes null values", `{"account_classification":null,"country":null,"status":null}`, nil, false},
+		{"Fails with non string values", `{"country":44}`, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var a AccountAttributes
+			err := json.Unmarshal([]byte(tc.json), &a)
+			if (err != nil) != tc.shouldFail {
+				t.Fatal("Fail condition didn't match! Got", err, "should've been", tc.shouldFail)
+			}
+			if tc.shouldFail {
+				return
+			}
+
+			unknown := a.UnknownValues()
+			if len(unknown) != len(tc.unknownFields) {
+				t.Fatal("Expected unknown values for", tc.unknownFields, "got", unknown)
+			}
+			for i, field := range tc.unknownFields {
+				if unknown[i].Field != field {
+					t.Fatal("Expected unknown values for", tc.unknownFields, "got", unknown)
+				}
+			}
+
+			// unknown values are sent back as they were received
+			b, err := json.Marshal(a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var expected, got map[string]interface{}
+			_ = json.Unmarshal([]byte(tc.json), &expected)
+			_ = json.Unmarshal(b, &got)
+			for k, v := range expected {
+				if v != nil && got[k] != v {
+					t.Fatal("Expected", k, "to be", v, "got", got[k])
+				}
+			}
+		})
+	}
+}
+
+func TestEnums_IsKnown(t *testing.T) {
+	if !CountryGB.IsKnown() || !Country("JP").IsKnown() || Country("gb").IsKnown() {
+		t.Fatal("Country codes weren't matched against ISO 3166-1")
+	}
+	if !CurrencyGBP.IsKnown() || !Currency("JPY").IsKnown() || Currency("pounds").IsKnown() {
+		t.Fatal("Currency codes weren't matched against ISO 4217")
+	}
+	if !AccountStatusPending.IsKnown() || AccountStatus("Pending").IsKnown() {
+		t.Fatal("Statuses weren't matched exactly")
+	}
+	if !ClassificationBusiness.IsKnown() || !BankIDCodeUSABA.IsKnown() {
+		t.Fatal("Expected constants to be known")
+	}
+}
+
+func TestAccountData_ValidateStatus(t *testing.T) {
+	d := withAttributes(func(a *AccountAttributes) {
+		a.Status = AccountStatus("archived").Ptr()
+	})
+
+	var validationErrs ValidationErrors
+	if err := d.Validate(); !errors.As(err, &validationErrs) || validationErrs[0].Field != "attributes.status" {
+		t.Fatal("Expected status validation error, got", err)
+	}
+
+	d.Attributes.Status = AccountStatusClosed.Ptr()
+	if err := d.Validate(); err != nil {
+		t.Fatal("Expected valid account, got", err)
+	}
+}
@@ -0,0 +1,117 @@
+package organisation_api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const requestIDHeader = "X-Request-Id"
+
+// Sentinel errors that an *APIError can be matched against with errors.Is.
+var (
+	ErrValidation   = errors.New("organisation api: validation failure")
+	ErrUnauthorized = errors.New("organisation api: unauthorized")
+	ErrNotFound     = errors.New("organisation api: not found")
+	ErrConflict     = errors.New("organisation api: conflict")
+	ErrRateLimited  = errors.New("organisation api: rate limited")
+	ErrServer       = errors.New("organisation api: server error")
+)
+
+// ErrMissingVersion Returned when updating an account without its current version.
+var ErrMissingVersion = errors.New("organisation api: account version is required")
+
+// APIError Error returned when the API answers with an unexpected status code.
+type APIError struct {
+	StatusCode int
+	ErrorCode  string
+	Message    string
+	RequestID  string
+	Body       []byte
+}
+
+// errorBody Auxiliary struct for decoding the error responses of the API.
+type errorBody struct {
+	ErrorMessage string `json:"error_message"`
+	ErrorCode    string `json:"error_code"`
+}
+
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+
+	if e.ErrorCode != "" {
+		return fmt.Sprintf("organisation api: status %d (%s): %s", e.StatusCode, e.ErrorCode, msg)
+	}
+
+	return fmt.Sprintf("organisation api: status %d: %s", e.StatusCode, msg)
+}
+
+// Is Matches the error against the sentinel errors based on the status code.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrValidation:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+
+	return false
+}
+
+// ConflictError Error returned when a created account conflicts with a different pre-existing one.
+// It unwraps to the *APIError of the 409 response, so it matches ErrConflict.
+type ConflictError struct {
+	Sent     *AccountData
+	Existing *AccountData
+	Err      *APIError
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("organisation api: account %s already exists with different attributes", e.Sent.ID)
+}
+
+// Unwrap Returns the underlying *APIError.
+func (e *ConflictError) Unwrap() error {
+	return e.Err
+}
+
+// newAPIError Builds an *APIError from the response, consuming and closing its body.
+func newAPIError(c *OrganisationApiClient, resp *http.Response) error {
+	defer closeBody(resp.Body)
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.logger().Error("reading error body failed", "error", err)
+		return err
+	}
+
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get(requestIDHeader),
+		Body:       b,
+	}
+
+	eb := errorBody{}
+	if len(b) > 0 && json.Unmarshal(b, &eb) == nil {
+		apiErr.Message = eb.ErrorMessage
+		apiErr.ErrorCode = eb.ErrorCode
+	}
+
+	c.logger().Debug("received API error", "status", apiErr.StatusCode, "error_code", apiErr.ErrorCode,
+		"request_id", apiErr.RequestID, "body", c.redactBody(b))
+
+	return apiErr
+}
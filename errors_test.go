@@ -0,0 +1,90 @@
+//go:build !integration
+// +build !integration
+
+package organisation_api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAPIError_Is(t *testing.T) {
+	testCases := []struct {
+		name       string
+		statusCode int
+		sentinel   error
+	}{
+		{"Matches bad request as validation", http.StatusBadRequest, ErrValidation},
+		{"Matches unprocessable entity as validation", http.StatusUnprocessableEntity, ErrValidation},
+		{"Matches unauthorized", http.StatusUnauthorized, ErrUnauthorized},
+		{"Matches not found", http.StatusNotFound, ErrNotFound},
+		{"Matches conflict", http.StatusConflict, ErrConflict},
+		{"Matches rate limited", http.StatusTooManyRequests, ErrRateLimited},
+		{"Matches server error", http.StatusBadGateway, ErrServer},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var err error = &APIError{StatusCode: tc.statusCode}
+			if !errors.Is(err, tc.sentinel) {
+				t.Fatal("Expected", err, "to match", tc.sentinel)
+			}
+			if tc.sentinel != ErrNotFound && errors.Is(err, ErrNotFound) {
+				t.Fatal("Unexpected match of", err, "with", ErrNotFound)
+			}
+		})
+	}
+}
+
+func TestOrganisationApiClient_FetchAccountNotFound(t *testing.T) {
+	c := &OrganisationApiClient{
+		Client: &http.Client{},
+		ClientConfig: &ClientConfig{
+			RootUrl:        defaultRootUrl,
+			DebugLog:       nil,
+			IsDebugEnabled: false,
+		},
+	}
+
+	body := &closeRecorder{Reader: strings.NewReader(`{"error_message":"record 123 does not exist","error_code":"not_found"}`)}
+	c.Client.Transport = roundTripAux(
+		func(r *http.Request) (*http.Response, error) {
+			h := http.Header{}
+			h.Set(requestIDHeader, "req-1")
+
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Header:     h,
+				Body:       body,
+			}, nil
+		},
+	)
+
+	_, err := c.FetchAccount(mockAccountData.ID)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatal("Expected not found error, got", err)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatal("Expected *APIError, got", err)
+	}
+	if apiErr.Message != "record 123 does not exist" || apiErr.ErrorCode != "not_found" || apiErr.RequestID != "req-1" {
+		t.Fatal("Error body wasn't decoded! Got", apiErr)
+	}
+	if !body.closed {
+		t.Fatal("Response body wasn't closed!")
+	}
+}
+
+type closeRecorder struct {
+	*strings.Reader
+	closed bool
+}
+
+func (r *closeRecorder) Close() error {
+	r.closed = true
+	return nil
+}
@@ -0,0 +1,505 @@
+// Package fakeapi In-memory fake of the Form3 organisation accounts API, backed by httptest, for running the client
+// tests offline.
+package fakeapi
+
+import (
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AccountsPath Path under which the fake serves the accounts resource.
+const AccountsPath = "/v1/organisation/accounts"
+
+const defaultPageSize = 100
+
+var (
+	uuidRegex    = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	countryRegex = regexp.MustCompile(`^[A-Z]{2}$`)
+)
+
+// filterAttributes Filters supported when listing, mapped to the attribute they match.
+var filterAttributes = []string{"bank_id", "bank_id_code", "account_number", "iban", "country", "customer_id"}
+
+// FaultFunc Decides whether a request gets a fault injected. Returning true short-circuits the request with the
+// returned status code.
+type FaultFunc func(r *http.Request) (status int, inject bool)
+
+// Option Configures the fake server.
+type Option func(s *Server)
+
+// WithLatency Delays every response by the given duration.
+func WithLatency(d time.Duration) Option {
+	return func(s *Server) {
+		s.latency = d
+	}
+}
+
+// WithErrorRate Answers the given fraction of the requests, between 0 and 1, with the given status code.
+func WithErrorRate(rate float64, status int) Option {
+	return func(s *Server) {
+		s.errorRate = rate
+		s.errorStatus = status
+	}
+}
+
+// WithFault Runs the fault function before handling every request.
+func WithFault(f FaultFunc) Option {
+	return func(s *Server) {
+		s.fault = f
+	}
+}
+
+// account Stored account. Attributes and relationships are kept as decoded JSON so that the fake doesn't lose unknown
+// fields.
+type account struct {
+	ID             string                 `json:"id"`
+	OrganisationID string                 `json:"organisation_id"`
+	Type           string                 `json:"type"`
+	Version        int64                  `json:"version"`
+	Attributes     map[string]interface{} `json:"attributes"`
+	Relationships  map[string]interface{} `json:"relationships,omitempty"`
+	CreatedOn      time.Time              `json:"created_on"`
+	ModifiedOn     time.Time              `json:"modified_on"`
+}
+
+// accountPayload Account as sent by clients, with an optional version.
+type accountPayload struct {
+	ID             string                 `json:"id"`
+	OrganisationID string                 `json:"organisation_id"`
+	Type           string                 `json:"type"`
+	Version        *int64                 `json:"version"`
+	Attributes     map[string]interface{} `json:"attributes"`
+	Relationships  map[string]interface{} `json:"relationships"`
+}
+
+type links struct {
+	First string `json:"first,omitempty"`
+	Last  string `json:"last,omitempty"`
+	Next  string `json:"next,omitempty"`
+	Prev  string `json:"prev,omitempty"`
+	Self  string `json:"self"`
+}
+
+// Server Fake accounts API. The embedded httptest.Server must be closed by the caller.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	accounts map[string]*account
+	rand     *rand.Rand
+
+	latency     time.Duration
+	errorRate   float64
+	errorStatus int
+	fault       FaultFunc
+
+	keyID     string
+	publicKey crypto.PublicKey
+}
+
+// NewServer Starts a fake server with no accounts.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		accounts: map[string]*account{},
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	return s
+}
+
+// RootURL Returns the organisation root URL of the fake, to be used as the client RootUrl.
+func (s *Server) RootURL() *url.URL {
+	u, err := url.Parse(s.URL + "/v1/organisation/")
+	if err != nil {
+		panic(err)
+	}
+
+	return u
+}
+
+// Len Returns the number of stored accounts.
+func (s *Server) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.accounts)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if s.latency > 0 {
+		select {
+		case <-time.After(s.latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if status, inject := s.injectFault(r); inject {
+		writeError(w, status, "injected fault")
+		return
+	}
+
+	if s.publicKey != nil {
+		if err := s.verifySignature(r); err != nil {
+			writeError(w, http.StatusUnauthorized, "invalid request signature: "+err.Error())
+			return
+		}
+	}
+
+	id := ""
+	switch {
+	case r.URL.Path == AccountsPath:
+	case strings.HasPrefix(r.URL.Path, AccountsPath+"/"):
+		id = strings.TrimPrefix(r.URL.Path, AccountsPath+"/")
+	default:
+		writeError(w, http.StatusNotFound, "unknown path "+r.URL.Path)
+		return
+	}
+
+	switch {
+	case id == "" && r.Method == http.MethodPost:
+		s.create(w, r)
+	case id == "" && r.Method == http.MethodGet:
+		s.list(w, r)
+	case id != "" && r.Method == http.MethodGet:
+		s.fetch(w, id)
+	case id != "" && r.Method == http.MethodPatch:
+		s.patch(w, r, id)
+	case id != "" && r.Method == http.MethodDelete:
+		s.delete(w, r, id)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method "+r.Method+" not allowed")
+	}
+}
+
+func (s *Server) injectFault(r *http.Request) (int, bool) {
+	if s.fault != nil {
+		if status, inject := s.fault(r); inject {
+			return status, true
+		}
+	}
+
+	if s.errorRate <= 0 {
+		return 0, false
+	}
+
+	s.mu.Lock()
+	roll := s.rand.Float64()
+	s.mu.Unlock()
+
+	return s.errorStatus, roll < s.errorRate
+}
+
+func (s *Server) create(w http.ResponseWriter, r *http.Request) {
+	p, ok := decodePayload(w, r)
+	if !ok {
+		return
+	}
+
+	if errs := validatePayload(p); len(errs) > 0 {
+		writeError(w, http.StatusBadRequest, "validation failure list:\n"+strings.Join(errs, "\n"))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.accounts[p.ID]; exists {
+		writeError(w, http.StatusConflict, "Account cannot be created as it violates a duplicate constraint")
+		return
+	}
+
+	now := time.Now().UTC()
+	a := &account{
+		ID:             p.ID,
+		OrganisationID: p.OrganisationID,
+		Type:           p.Type,
+		Version:        0,
+		Attributes:     p.Attributes,
+		Relationships:  p.Relationships,
+		CreatedOn:      now,
+		ModifiedOn:     now,
+	}
+	s.accounts[a.ID] = a
+
+	writeAccount(w, http.StatusCreated, a)
+}
+
+func (s *Server) fetch(w http.ResponseWriter, id string) {
+	if !uuidRegex.MatchString(id) {
+		writeError(w, http.StatusBadRequest, "id is not a valid uuid")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.accounts[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("record %s does not exist", id))
+		return
+	}
+
+	writeAccount(w, http.StatusOK, a)
+}
+
+func (s *Server) patch(w http.ResponseWriter, r *http.Request, id string) {
+	if !uuidRegex.MatchString(id) {
+		writeError(w, http.StatusBadRequest, "id is not a valid uuid")
+		return
+	}
+
+	p, ok := decodePayload(w, r)
+	if !ok {
+		return
+	}
+	if p.Version == nil {
+		writeError(w, http.StatusBadRequest, "invalid version number")
+		return
+	}
+	if p.ID != "" && p.ID != id {
+		writeError(w, http.StatusBadRequest, "id in body doesn't match path")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.accounts[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("record %s does not exist", id))
+		return
+	}
+	if *p.Version != a.Version {
+		writeError(w, http.StatusConflict, "invalid version")
+		return
+	}
+
+	// copy on write, so readers of the previous version never see a partial update
+	attributes := make(map[string]interface{}, len(a.Attributes))
+	for k, v := range a.Attributes {
+		attributes[k] = v
+	}
+	for k, v := range p.Attributes {
+		attributes[k] = v
+	}
+
+	updated := *a
+	updated.Attributes = attributes
+	if p.Relationships != nil {
+		updated.Relationships = p.Relationships
+	}
+	updated.Version++
+	updated.ModifiedOn = time.Now().UTC()
+	s.accounts[id] = &updated
+
+	writeAccount(w, http.StatusOK, &updated)
+}
+
+func (s *Server) delete(w http.ResponseWriter, r *http.Request, id string) {
+	if !uuidRegex.MatchString(id) {
+		writeError(w, http.StatusBadRequest, "id is not a valid uuid")
+		return
+	}
+
+	version, err := strconv.ParseInt(r.URL.Query().Get("version"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid version number")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.accounts[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("record %s does not exist", id))
+		return
+	}
+	if a.Version != version {
+		writeError(w, http.StatusConflict, "invalid version")
+		return
+	}
+
+	delete(s.accounts, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	pageNumber, pageSize := 0, defaultPageSize
+	if v := q.Get("page[number]"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "invalid page number")
+			return
+		}
+		pageNumber = n
+	}
+	if v := q.Get("page[size]"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			writeError(w, http.StatusBadRequest, "invalid page size")
+			return
+		}
+		pageSize = n
+	}
+
+	s.mu.Lock()
+	matched := make([]*account, 0, len(s.accounts))
+	for _, a := range s.accounts {
+		if matchesFilters(a, q) {
+			matched = append(matched, a)
+		}
+	}
+	s.mu.Unlock()
+
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].CreatedOn.Equal(matched[j].CreatedOn) {
+			return matched[i].ID < matched[j].ID
+		}
+		return matched[i].CreatedOn.Before(matched[j].CreatedOn)
+	})
+
+	lastPage := 0
+	if len(matched) > 0 {
+		lastPage = (len(matched) - 1) / pageSize
+	}
+
+	page := []*account{}
+	if start := pageNumber * pageSize; start < len(matched) {
+		end := start + pageSize
+		if end > len(matched) {
+			end = len(matched)
+		}
+		page = matched[start:end]
+	}
+
+	pageLink := func(n int) string {
+		lq := url.Values{}
+		for k, v := range q {
+			lq[k] = v
+		}
+		lq.Set("page[number]", strconv.Itoa(n))
+		lq.Set("page[size]", strconv.Itoa(pageSize))
+
+		return AccountsPath + "?" + lq.Encode()
+	}
+
+	l := links{
+		First: pageLink(0),
+		Last:  pageLink(lastPage),
+		Self:  pageLink(pageNumber),
+	}
+	if pageNumber < lastPage {
+		l.Next = pageLink(pageNumber + 1)
+	}
+	if pageNumber > 0 {
+		l.Prev = pageLink(pageNumber - 1)
+	}
+
+	writeJSON(w, http.StatusOK, struct {
+		Data  []*account `json:"data"`
+		Links links      `json:"links"`
+	}{page, l})
+}
+
+func matchesFilters(a *account, q url.Values) bool {
+	for _, name := range filterAttributes {
+		v := q.Get("filter[" + name + "]")
+		if v == "" {
+			continue
+		}
+
+		actual, _ := a.Attributes[name].(string)
+		found := false
+		for _, accepted := range strings.Split(v, ",") {
+			if accepted == actual {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func decodePayload(w http.ResponseWriter, r *http.Request) (*accountPayload, bool) {
+	holder := struct {
+		Data *accountPayload `json:"data"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&holder); err != nil || holder.Data == nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return nil, false
+	}
+
+	return holder.Data, true
+}
+
+// validatePayload Mimics the validation of the real API for the fields it always requires.
+func validatePayload(p *accountPayload) []string {
+	var errs []string
+
+	if !uuidRegex.MatchString(p.ID) {
+		errs = append(errs, "id in body must be of type uuid: \""+p.ID+"\"")
+	}
+	if !uuidRegex.MatchString(p.OrganisationID) {
+		errs = append(errs, "organisation_id in body must be of type uuid: \""+p.OrganisationID+"\"")
+	}
+	if p.Type != "accounts" {
+		errs = append(errs, "type in body should be one of [accounts]")
+	}
+	if p.Attributes == nil {
+		return append(errs, "attributes in body is required")
+	}
+
+	if country, _ := p.Attributes["country"].(string); !countryRegex.MatchString(country) {
+		errs = append(errs, "country in body should match '^[A-Z]{2}$'")
+	}
+	if names, _ := p.Attributes["name"].([]interface{}); len(names) == 0 || len(names) > 4 {
+		errs = append(errs, "name in body should have between 1 and 4 items")
+	}
+
+	return errs
+}
+
+func writeAccount(w http.ResponseWriter, status int, a *account) {
+	writeJSON(w, status, struct {
+		Data  *account `json:"data"`
+		Links links    `json:"links"`
+	}{a, links{Self: AccountsPath + "/" + a.ID}})
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, struct {
+		ErrorMessage string `json:"error_message"`
+	}{msg})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/vnd.api+json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(v)
+}
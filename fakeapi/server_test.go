@@ -0,0 +1,153 @@
+package fakeapi_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	organisation_api "github.com/CG-SS/organisation-api"
+	"github.com/CG-SS/organisation-api/fakeapi"
+)
+
+func newAccount(n int) organisation_api.AccountData {
+	return organisation_api.AccountData{
+		Attributes: &organisation_api.AccountAttributes{
+			BankID:     "400302",
+			BankIDCode: organisation_api.BankIDCodeGBDSC,
+			Country:    organisation_api.CountryGB.Ptr(),
+			Name:       []string{"Kelvin", "Klein"},
+		},
+		ID:             fmt.Sprintf("123e4567-e89b-12d3-a456-%012d", n),
+		OrganisationID: "123e4567-e89b-12d3-a456-426614174111",
+		Type:           "accounts",
+	}
+}
+
+func newClient(s *fakeapi.Server) *organisation_api.OrganisationApiClient {
+	return &organisation_api.OrganisationApiClient{
+		Client: s.Client(),
+		ClientConfig: &organisation_api.ClientConfig{
+			RootUrl: s.RootURL(),
+		},
+	}
+}
+
+func TestServer_lifecycle(t *testing.T) {
+	s := fakeapi.NewServer()
+	defer s.Close()
+	c := newClient(s)
+
+	account := newAccount(1)
+	created, err := c.CreateAccount(account)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *created.Data.Version != 0 {
+		t.Fatal("Expected version 0, got", *created.Data.Version)
+	}
+
+	if _, err := c.CreateAccount(account); !errors.Is(err, organisation_api.ErrConflict) {
+		t.Fatal("Expected duplicate conflict, got", err)
+	}
+	if _, err := c.CreateAccount(organisation_api.AccountData{}); !errors.Is(err, organisation_api.ErrValidation) {
+		t.Fatal("Expected validation error, got", err)
+	}
+
+	updated, err := c.UpdateAccount(*created.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.UpdateAccount(*created.Data); !errors.Is(err, organisation_api.ErrConflict) {
+		t.Fatal("Expected stale version conflict, got", err)
+	}
+
+	if _, err := c.FetchAccount("123"); !errors.Is(err, organisation_api.ErrValidation) {
+		t.Fatal("Expected invalid uuid error, got", err)
+	}
+	if _, err := c.DeleteAccount(account.ID, 0); !errors.Is(err, organisation_api.ErrConflict) {
+		t.Fatal("Expected stale version conflict, got", err)
+	}
+	if _, err := c.DeleteAccount(account.ID, *updated.Data.Version); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.FetchAccount(account.ID); !errors.Is(err, organisation_api.ErrNotFound) {
+		t.Fatal("Expected not found error, got", err)
+	}
+	if s.Len() != 0 {
+		t.Fatal("Expected no accounts left, got", s.Len())
+	}
+}
+
+func TestServer_list(t *testing.T) {
+	s := fakeapi.NewServer()
+	defer s.Close()
+	c := newClient(s)
+
+	for i := 0; i < 5; i++ {
+		account := newAccount(i)
+		if i%2 == 0 {
+			account.Attributes.BankID = "400303"
+		}
+		if _, err := c.CreateAccount(account); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	page, err := c.ListAccounts(organisation_api.ListOptions{PageSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Data) != 2 || page.Links.Next == "" || page.Links.Prev != "" {
+		t.Fatal("Wrong first page! Got", page.Data, page.Links)
+	}
+
+	it := c.IterateAccounts(organisation_api.ListOptions{
+		PageSize: 2,
+		Filter:   organisation_api.AccountFilter{BankID: []string{"400303"}},
+	})
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if it.Err() != nil {
+		t.Fatal(it.Err())
+	}
+	if count != 3 {
+		t.Fatal("Expected 3 filtered accounts, got", count)
+	}
+}
+
+func TestServer_faults(t *testing.T) {
+	s := fakeapi.NewServer(
+		fakeapi.WithLatency(20*time.Millisecond),
+		fakeapi.WithErrorRate(1, http.StatusServiceUnavailable),
+	)
+	defer s.Close()
+	c := newClient(s)
+
+	start := time.Now()
+	_, err := c.FetchAccount(newAccount(1).ID)
+	if !errors.Is(err, organisation_api.ErrServer) {
+		t.Fatal("Expected injected server error, got", err)
+	}
+	if time.Since(start) < 20*time.Millisecond {
+		t.Fatal("Latency wasn't injected!")
+	}
+
+	calls := 0
+	s = fakeapi.NewServer(fakeapi.WithFault(func(r *http.Request) (int, bool) {
+		calls++
+		return http.StatusTooManyRequests, calls == 1
+	}))
+	defer s.Close()
+	c = newClient(s)
+
+	if _, err := c.FetchAccount(newAccount(1).ID); !errors.Is(err, organisation_api.ErrRateLimited) {
+		t.Fatal("Expected injected rate limit, got", err)
+	}
+	if _, err := c.FetchAccount(newAccount(1).ID); !errors.Is(err, organisation_api.ErrNotFound) {
+		t.Fatal("Expected not found after the fault, got", err)
+	}
+}
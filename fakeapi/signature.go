@@ -0,0 +1,129 @@
+package fakeapi
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const maxClockSkew = 5 * time.Minute
+
+// WithSignatureVerification Rejects with 401 every request that isn't signed, following the draft-cavage HTTP
+// Signatures scheme, with the private key of the given RSA or ECDSA public key.
+func WithSignatureVerification(keyID string, publicKey crypto.PublicKey) Option {
+	return func(s *Server) {
+		s.keyID = keyID
+		s.publicKey = publicKey
+	}
+}
+
+// verifySignature Checks the Authorization signature and the Digest of the request, restoring its body.
+func (s *Server) verifySignature(r *http.Request) error {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	params, err := parseSignatureParams(r.Header.Get("Authorization"))
+	if err != nil {
+		return err
+	}
+	if params["keyId"] != s.keyID {
+		return fmt.Errorf("unknown key %q", params["keyId"])
+	}
+
+	headers := strings.Fields(params["headers"])
+	covered := map[string]bool{}
+	for _, h := range headers {
+		covered[h] = true
+	}
+	for _, h := range []string{"(request-target)", "host", "date"} {
+		if !covered[h] {
+			return fmt.Errorf("signature must cover %s", h)
+		}
+	}
+
+	date, err := http.ParseTime(r.Header.Get("Date"))
+	if err != nil {
+		return errors.New("invalid date header")
+	}
+	if skew := time.Since(date); skew > maxClockSkew || skew < -maxClockSkew {
+		return errors.New("date header is too skewed")
+	}
+
+	if len(body) > 0 {
+		if !covered["digest"] {
+			return errors.New("signature must cover digest")
+		}
+
+		sum := sha256.Sum256(body)
+		if r.Header.Get("Digest") != "SHA-256="+base64.StdEncoding.EncodeToString(sum[:]) {
+			return errors.New("digest doesn't match the body")
+		}
+	}
+
+	lines := make([]string, len(headers))
+	for i, h := range headers {
+		switch h {
+		case "(request-target)":
+			lines[i] = h + ": " + strings.ToLower(r.Method) + " " + r.URL.RequestURI()
+		case "host":
+			lines[i] = h + ": " + r.Host
+		default:
+			lines[i] = h + ": " + r.Header.Get(h)
+		}
+	}
+	hashed := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+
+	signature, err := base64.StdEncoding.DecodeString(params["signature"])
+	if err != nil {
+		return errors.New("signature isn't base64")
+	}
+
+	switch key := s.publicKey.(type) {
+	case *rsa.PublicKey:
+		if params["algorithm"] != "rsa-sha256" {
+			return fmt.Errorf("unexpected algorithm %q", params["algorithm"])
+		}
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, hashed[:], signature)
+	case *ecdsa.PublicKey:
+		if params["algorithm"] != "ecdsa-sha256" {
+			return fmt.Errorf("unexpected algorithm %q", params["algorithm"])
+		}
+		if !ecdsa.VerifyASN1(key, hashed[:], signature) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unsupported public key %T", s.publicKey)
+}
+
+// parseSignatureParams Parses `Signature keyId="...",algorithm="...",headers="...",signature="..."`.
+func parseSignatureParams(header string) (map[string]string, error) {
+	const prefix = "Signature "
+	if !strings.HasPrefix(header, prefix) {
+		return nil, errors.New("missing signature")
+	}
+
+	params := map[string]string{}
+	for _, part := range strings.Split(strings.TrimPrefix(header, prefix), ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 || len(kv[1]) < 2 || kv[1][0] != '"' || kv[1][len(kv[1])-1] != '"' {
+			return nil, fmt.Errorf("malformed signature parameter %q", part)
+		}
+		params[kv[0]] = kv[1][1 : len(kv[1])-1]
+	}
+
+	return params, nil
+}
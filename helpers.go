@@ -4,18 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"io"
-	"log"
 	"net/http"
 	"net/url"
 	"path"
+	"reflect"
+	"strconv"
+	"strings"
 )
 
-func logMsg(logger *log.Logger, msg ...interface{}) {
-	if logger != nil {
-		logger.Println(msg...)
-	}
-}
-
 func createRequest(ctx context.Context, method string, url url.URL, body io.Reader) (*http.Request, error) {
 	req, err := http.NewRequestWithContext(ctx, method, url.String(), body)
 	if err != nil {
@@ -23,14 +19,38 @@ func createRequest(ctx context.Context, method string, url url.URL, body io.Read
 	}
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
 	req.Header.Set("Accept", "application/json; charset=utf-8")
+	if key := idempotencyKeyFromContext(ctx); key != "" {
+		req.Header.Set(idempotencyKeyHeader, key)
+	}
 
 	return req, err
 }
 
+// newRequest Creates the request, adding the user agent and default headers of the client config.
+func (c *OrganisationApiClient) newRequest(ctx context.Context, method string, url url.URL, body io.Reader) (*http.Request, error) {
+	req, err := createRequest(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, values := range c.ClientConfig.DefaultHeaders {
+		for _, v := range values {
+			req.Header.Add(k, v)
+		}
+	}
+	if c.ClientConfig.UserAgent != "" {
+		req.Header.Set("User-Agent", c.ClientConfig.UserAgent)
+	}
+
+	return req, nil
+}
+
 func buildAccountsUrl(c *OrganisationApiClient) (*url.URL, error) {
-	clientRootUrlPath := c.ClientConfig.RootUrl.Path
+	if c.ClientConfig.RootUrl == defaultRootUrl && defaultRootUrlErr != nil {
+		return nil, defaultRootUrlErr
+	}
 
-	logMsg(c.ClientConfig.DebugLog, "Joining paths", clientRootUrlPath, "and", accountsPath)
+	clientRootUrlPath := c.ClientConfig.RootUrl.Path
 
 	requestUrl, err := url.Parse(path.Join(clientRootUrlPath, accountsPath))
 
@@ -44,27 +64,28 @@ func buildAccountsUrl(c *OrganisationApiClient) (*url.URL, error) {
 	return requestUrl, nil
 }
 
-func fetchAccountDataFromBody(c *OrganisationApiClient, resp *http.Response) (*AccountData, error) {
+func fetchAccountDataFromBody(c *OrganisationApiClient, resp *http.Response) (*AccountData, *Links, error) {
 	b, err := io.ReadAll(resp.Body)
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	if c.ClientConfig.IsDebugEnabled {
-		rawBody := string(b)
-		logMsg(c.ClientConfig.DebugLog, "Received raw msg", rawBody)
-	}
+	c.logger().Debug("received body", "body", c.redactBody(b))
 
 	data := dataHolder{}
 	err = json.Unmarshal(b, &data)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	logMsg(c.ClientConfig.DebugLog, "Unmarshalled data from body", data)
+	if data.Data.Attributes != nil {
+		for _, fe := range data.Data.Attributes.UnknownValues() {
+			c.logger().Warn("received unknown value", "field", fe.Field, "message", fe.Message)
+		}
+	}
 
-	return &data.Data, nil
+	return &data.Data, data.Links, nil
 }
 
 func closeBody(body io.ReadCloser) {
@@ -73,3 +94,106 @@ func closeBody(body io.ReadCloser) {
 		panic(err)
 	}
 }
+
+func encodeListOptions(opts ListOptions) string {
+	q := url.Values{}
+
+	if opts.PageNumber > 0 {
+		q.Set("page[number]", strconv.Itoa(opts.PageNumber))
+	}
+	if opts.PageSize > 0 {
+		q.Set("page[size]", strconv.Itoa(opts.PageSize))
+	}
+
+	filters := []struct {
+		name   string
+		values []string
+	}{
+		{"bank_id", opts.Filter.BankID},
+		{"bank_id_code", opts.Filter.BankIDCode},
+		{"account_number", opts.Filter.AccountNumber},
+		{"iban", opts.Filter.Iban},
+		{"country", opts.Filter.Country},
+		{"customer_id", opts.Filter.CustomerID},
+	}
+	for _, f := range filters {
+		if len(f.values) > 0 {
+			q.Set("filter["+f.name+"]", strings.Join(f.values, ","))
+		}
+	}
+
+	return q.Encode()
+}
+
+func fetchAccountListFromBody(c *OrganisationApiClient, resp *http.Response) (*accountListHolder, error) {
+	b, err := io.ReadAll(resp.Body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.logger().Debug("received body", "body", c.redactBody(b))
+
+	list := accountListHolder{}
+	err = json.Unmarshal(b, &list)
+	if err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+// accountMatches Checks whether every field set on sent has the same value on existing. Fields only set by the
+// server, such as the version or the status, are ignored.
+func accountMatches(sent AccountData, existing AccountData) (bool, error) {
+	// assigned by the server
+	sent.Version, sent.CreatedOn, sent.ModifiedOn = nil, nil, nil
+	existing.Version, existing.CreatedOn, existing.ModifiedOn = nil, nil, nil
+
+	s, err := toJsonMap(sent)
+	if err != nil {
+		return false, err
+	}
+	e, err := toJsonMap(existing)
+	if err != nil {
+		return false, err
+	}
+
+	return isJsonSubset(s, e), nil
+}
+
+func toJsonMap(v interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	m := map[string]interface{}{}
+	err = json.Unmarshal(b, &m)
+
+	return m, err
+}
+
+func isJsonSubset(subset map[string]interface{}, superset map[string]interface{}) bool {
+	for k, v := range subset {
+		other, ok := superset[k]
+		if !ok {
+			return false
+		}
+
+		vm, isMap := v.(map[string]interface{})
+		om, otherIsMap := other.(map[string]interface{})
+		if isMap && otherIsMap {
+			if !isJsonSubset(vm, om) {
+				return false
+			}
+			continue
+		}
+
+		if !reflect.DeepEqual(v, other) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -45,22 +45,20 @@ func TestOrganisationApiClient_createRequest(t *testing.T) {
 
 func TestOrganisationApiClient_fetchAccountDataFromBody(t *testing.T) {
 	c := DefaultClient
-	country := "GB"
-	accType := "Personal"
 
 	testCases := []struct {
 		name     string
 		expected dataHolder
 	}{
-		{"Fetch account from body", dataHolder{AccountData{
+		{"Fetch account from body", dataHolder{Data: AccountData{
 			Attributes: &AccountAttributes{
-				AccountClassification: &accType,
+				AccountClassification: ClassificationPersonal.Ptr(),
 				AccountNumber:         "10000004",
 				BankID:                "400302",
-				BankIDCode:            "GBDSC",
-				BaseCurrency:          "GBP",
+				BankIDCode:            BankIDCodeGBDSC,
+				BaseCurrency:          CurrencyGBP,
 				Bic:                   "NWBKGB42",
-				Country:               &country,
+				Country:               CountryGB.Ptr(),
 				Iban:                  "GB28NWBK40030212764204",
 				Name:                  []string{"Kelvin", "Klein"},
 			},
@@ -69,15 +67,15 @@ func TestOrganisationApiClient_fetchAccountDataFromBody(t *testing.T) {
 			Type:           "accounts",
 		}},
 		},
-		{"Fetch another account from body", dataHolder{AccountData{
+		{"Fetch another account from body", dataHolder{Data: AccountData{
 			Attributes: &AccountAttributes{
-				AccountClassification: &accType,
+				AccountClassification: ClassificationPersonal.Ptr(),
 				AccountNumber:         "10000004",
 				BankID:                "400302",
-				BankIDCode:            "GBDSC",
-				BaseCurrency:          "GBP",
+				BankIDCode:            BankIDCodeGBDSC,
+				BaseCurrency:          CurrencyGBP,
 				Bic:                   "NWBKGB42",
-				Country:               &country,
+				Country:               CountryGB.Ptr(),
 				Iban:                  "GB28NWBK40030212764204",
 				Name:                  []string{"Kelvin", "Klein"},
 			},
@@ -98,11 +96,11 @@ func TestOrganisationApiClient_fetchAccountDataFromBody(t *testing.T) {
 			resp := http.Response{
 				Body: ioutil.NopCloser(strings.NewReader(string(j))),
 			}
-			dataFromBody, err := fetchAccountDataFromBody(c, &resp)
+			dataFromBody, _, err := fetchAccountDataFromBody(c, &resp)
 			if err != nil {
 				t.Fatal(err)
 			}
-			d := dataHolder{*dataFromBody}
+			d := dataHolder{Data: *dataFromBody}
 			// maybe compare all fields
 			if d.Data.ID != tc.expected.Data.ID {
 				t.Fatal("Expected", tc.expected, "got", dataFromBody)
@@ -0,0 +1,66 @@
+package iban
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidBIC Returned when a string isn't a valid BIC.
+var ErrInvalidBIC = errors.New("iban: invalid BIC")
+
+const primaryOfficeBranch = "XXX"
+
+// BIC Parsed Business Identifier Code (ISO 9362).
+type BIC struct {
+	InstitutionCode string
+	CountryCode     string
+	LocationCode    string
+	BranchCode      string
+}
+
+// ParseBIC Parses an 8 or 11 character BIC. 8 character BICs get the primary office branch code XXX.
+func ParseBIC(s string) (*BIC, error) {
+	s = normalize(s)
+	if len(s) != 8 && len(s) != 11 {
+		return nil, fmt.Errorf("%w: must have 8 or 11 characters, got %d", ErrInvalidBIC, len(s))
+	}
+
+	bic := &BIC{
+		InstitutionCode: s[:4],
+		CountryCode:     s[4:6],
+		LocationCode:    s[6:8],
+		BranchCode:      primaryOfficeBranch,
+	}
+	if len(s) == 11 {
+		bic.BranchCode = s[8:]
+	}
+
+	switch {
+	case !matches(bic.InstitutionCode, 'c'):
+		return nil, fmt.Errorf("%w: invalid institution code %q", ErrInvalidBIC, bic.InstitutionCode)
+	case !matches(bic.CountryCode, 'a'):
+		return nil, fmt.Errorf("%w: invalid country code %q", ErrInvalidBIC, bic.CountryCode)
+	case !matches(bic.LocationCode, 'c'):
+		return nil, fmt.Errorf("%w: invalid location code %q", ErrInvalidBIC, bic.LocationCode)
+	case !matches(bic.BranchCode, 'c'):
+		return nil, fmt.Errorf("%w: invalid branch code %q", ErrInvalidBIC, bic.BranchCode)
+	}
+
+	return bic, nil
+}
+
+// String Returns the 11 character form of the BIC.
+func (b BIC) String() string {
+	return b.InstitutionCode + b.CountryCode + b.LocationCode + b.BranchCode
+}
+
+// IsPrimaryOffice Checks whether the BIC identifies the primary office of the institution.
+func (b BIC) IsPrimaryOffice() bool {
+	return b.BranchCode == primaryOfficeBranch
+}
+
+// IsTest Checks whether the BIC is a test BIC, which have a 0 as the second character of the location code. False for
+// a BIC without location code, such as the zero value.
+func (b BIC) IsTest() bool {
+	return len(b.LocationCode) >= 2 && b.LocationCode[1] == '0'
+}
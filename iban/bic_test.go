@@ -0,0 +1,61 @@
+package iban
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseBIC(t *testing.T) {
+	testCases := []struct {
+		name     string
+		bic      string
+		expected *BIC
+	}{
+		{"Parses 8 character BIC", "NWBKGB42", &BIC{"NWBK", "GB", "42", "XXX"}},
+		{"Parses 11 character BIC", "DEUTDEFF500", &BIC{"DEUT", "DE", "FF", "500"}},
+		{"Rejects short BIC", "NWBKGB4", nil},
+		{"Rejects numeric country", "NWBK4242", nil},
+		{"Rejects symbols", "NWBKGB4-", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bic, err := ParseBIC(tc.bic)
+			if tc.expected == nil {
+				if !errors.Is(err, ErrInvalidBIC) {
+					t.Fatal("Expected invalid BIC error, got", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *bic != *tc.expected {
+				t.Fatal("Expected", tc.expected, "got", bic)
+			}
+		})
+	}
+}
+
+func TestBIC_flags(t *testing.T) {
+	bic, err := ParseBIC("NWBKGB42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bic.IsPrimaryOffice() || bic.IsTest() {
+		t.Fatal("Wrong flags for", bic)
+	}
+
+	bic, err = ParseBIC("NWBKGB20123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bic.IsPrimaryOffice() || !bic.IsTest() {
+		t.Fatal("Wrong flags for", bic)
+	}
+
+	if (BIC{}).IsTest() {
+		t.Fatal("Zero value BIC shouldn't be a test BIC")
+	}
+}
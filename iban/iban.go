@@ -0,0 +1,357 @@
+// Package iban Parses, validates and generates IBANs (ISO 13616) and BICs (ISO 9362).
+package iban
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Errors returned when parsing or generating IBANs.
+var (
+	ErrUnsupportedCountry = errors.New("iban: unsupported country")
+	ErrInvalidLength      = errors.New("iban: invalid length")
+	ErrInvalidFormat      = errors.New("iban: invalid format")
+	ErrInvalidChecksum    = errors.New("iban: invalid checksum")
+)
+
+// Bounds of the IBAN length across every country.
+const (
+	minLength = 15
+	maxLength = 34
+)
+
+type segmentKind int
+
+const (
+	bankCode segmentKind = iota
+	branchCode
+	accountNumber
+	nationalCheck
+)
+
+// segment Part of a BBAN, with its length and charset: 'n' digits, 'a' upper case letters and 'c' alphanumerics.
+type segment struct {
+	kind    segmentKind
+	length  int
+	charset byte
+}
+
+// structure BBAN structure of a country. National check digits that sit after the account number are included in it.
+type structure []segment
+
+func (s structure) length() int {
+	l := 0
+	for _, seg := range s {
+		l += seg.length
+	}
+
+	return l
+}
+
+func b(length int, charset byte) segment  { return segment{bankCode, length, charset} }
+func br(length int, charset byte) segment { return segment{branchCode, length, charset} }
+func a(length int, charset byte) segment  { return segment{accountNumber, length, charset} }
+func k(length int, charset byte) segment  { return segment{nationalCheck, length, charset} }
+
+var structures = map[string]structure{
+	"AD": {b(4, 'n'), br(4, 'n'), a(12, 'c')},
+	"AE": {b(3, 'n'), a(16, 'n')},
+	"AL": {b(3, 'n'), br(4, 'n'), k(1, 'n'), a(16, 'c')},
+	"AT": {b(5, 'n'), a(11, 'n')},
+	"AZ": {b(4, 'a'), a(20, 'c')},
+	"BA": {b(3, 'n'), br(3, 'n'), a(8, 'n'), k(2, 'n')},
+	"BE": {b(3, 'n'), a(9, 'n')},
+	"BG": {b(4, 'a'), br(4, 'n'), a(10, 'c')},
+	"BH": {b(4, 'a'), a(14, 'c')},
+	"BR": {b(8, 'n'), br(5, 'n'), a(10, 'n'), k(1, 'a'), k(1, 'c')},
+	"BY": {b(4, 'c'), br(4, 'n'), a(16, 'c')},
+	"CH": {b(5, 'n'), a(12, 'c')},
+	"CR": {k(1, 'n'), b(3, 'n'), a(14, 'n')},
+	"CY": {b(3, 'n'), br(5, 'n'), a(16, 'c')},
+	"CZ": {b(4, 'n'), a(16, 'n')},
+	"DE": {b(8, 'n'), a(10, 'n')},
+	"DK": {b(4, 'n'), a(10, 'n')},
+	"DO": {b(4, 'c'), a(20, 'n')},
+	"EE": {b(2, 'n'), a(14, 'n')},
+	"EG": {b(4, 'n'), br(4, 'n'), a(17, 'n')},
+	"ES": {b(4, 'n'), br(4, 'n'), a(12, 'n')},
+	"FI": {b(3, 'n'), a(11, 'n')},
+	"FO": {b(4, 'n'), a(9, 'n'), k(1, 'n')},
+	"FR": {b(5, 'n'), br(5, 'n'), a(13, 'c')},
+	"GB": {b(4, 'a'), br(6, 'n'), a(8, 'n')},
+	"GE": {b(2, 'a'), a(16, 'n')},
+	"GI": {b(4, 'a'), a(15, 'c')},
+	"GL": {b(4, 'n'), a(9, 'n'), k(1, 'n')},
+	"GR": {b(3, 'n'), br(4, 'n'), a(16, 'c')},
+	"GT": {b(4, 'c'), a(20, 'c')},
+	"HR": {b(7, 'n'), a(10, 'n')},
+	"HU": {b(3, 'n'), br(4, 'n'), a(17, 'n')},
+	"IE": {b(4, 'a'), br(6, 'n'), a(8, 'n')},
+	"IL": {b(3, 'n'), br(3, 'n'), a(13, 'n')},
+	"IQ": {b(4, 'a'), br(3, 'n'), a(12, 'n')},
+	"IS": {b(4, 'n'), a(18, 'n')},
+	"IT": {k(1, 'a'), b(5, 'n'), br(5, 'n'), a(12, 'c')},
+	"JO": {b(4, 'a'), br(4, 'n'), a(18, 'c')},
+	"KW": {b(4, 'a'), a(22, 'c')},
+	"KZ": {b(3, 'n'), a(13, 'c')},
+	"LB": {b(4, 'n'), a(20, 'c')},
+	"LC": {b(4, 'a'), a(24, 'c')},
+	"LI": {b(5, 'n'), a(12, 'c')},
+	"LT": {b(5, 'n'), a(11, 'n')},
+	"LU": {b(3, 'n'), a(13, 'c')},
+	"LV": {b(4, 'a'), a(13, 'c')},
+	"MC": {b(5, 'n'), br(5, 'n'), a(13, 'c')},
+	"MD": {b(2, 'c'), a(18, 'c')},
+	"ME": {b(3, 'n'), a(13, 'n'), k(2, 'n')},
+	"MK": {b(3, 'n'), a(10, 'c'), k(2, 'n')},
+	"MR": {b(5, 'n'), br(5, 'n'), a(11, 'n'), k(2, 'n')},
+	"MT": {b(4, 'a'), br(5, 'n'), a(18, 'c')},
+	"NL": {b(4, 'a'), a(10, 'n')},
+	"NO": {b(4, 'n'), a(7, 'n')},
+	"PK": {b(4, 'a'), a(16, 'c')},
+	"PL": {b(8, 'n'), a(16, 'n')},
+	"PS": {b(4, 'a'), a(21, 'c')},
+	"PT": {b(4, 'n'), br(4, 'n'), a(13, 'n')},
+	"QA": {b(4, 'a'), a(21, 'c')},
+	"RO": {b(4, 'a'), a(16, 'c')},
+	"RS": {b(3, 'n'), a(13, 'n'), k(2, 'n')},
+	"SA": {b(2, 'n'), a(18, 'c')},
+	"SE": {b(3, 'n'), a(17, 'n')},
+	"SI": {b(5, 'n'), a(10, 'n')},
+	"SK": {b(4, 'n'), a(16, 'n')},
+	"SM": {k(1, 'a'), b(5, 'n'), br(5, 'n'), a(12, 'c')},
+	"SV": {b(4, 'a'), a(20, 'n')},
+	"TL": {b(3, 'n'), a(14, 'n'), k(2, 'n')},
+	"TN": {b(2, 'n'), br(3, 'n'), a(13, 'n'), k(2, 'n')},
+	"TR": {b(5, 'n'), k(1, 'n'), a(16, 'c')},
+	"UA": {b(6, 'n'), a(19, 'c')},
+	"VA": {b(3, 'n'), a(15, 'n')},
+	"VG": {b(4, 'a'), a(16, 'n')},
+	"XK": {b(2, 'n'), br(2, 'n'), a(10, 'n'), k(2, 'n')},
+}
+
+// IBAN Parsed International Bank Account Number.
+type IBAN struct {
+	CountryCode   string
+	CheckDigits   string
+	BBAN          string
+	BankCode      string
+	BranchCode    string
+	AccountNumber string
+}
+
+// IsSupported Checks whether IBANs of the given ISO 3166-1 alpha-2 country can be parsed and generated.
+func IsSupported(country string) bool {
+	_, ok := structures[country]
+	return ok
+}
+
+// Length Returns the IBAN length of the given country, or 0 when it isn't supported.
+func Length(country string) int {
+	s, ok := structures[country]
+	if !ok {
+		return 0
+	}
+
+	return 4 + s.length()
+}
+
+// Parse Parses an IBAN in electronic or print format, checking its length, structure and mod-97 checksum.
+func Parse(s string) (*IBAN, error) {
+	s = normalize(s)
+	if len(s) < 4 {
+		return nil, ErrInvalidLength
+	}
+
+	country := s[:2]
+	st, ok := structures[country]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedCountry, country)
+	}
+	if len(s) != 4+st.length() {
+		return nil, fmt.Errorf("%w: %s IBANs have %d characters, got %d", ErrInvalidLength, country, 4+st.length(), len(s))
+	}
+	if !matches(s[2:4], 'n') {
+		return nil, fmt.Errorf("%w: check digits must be numeric", ErrInvalidFormat)
+	}
+
+	i := &IBAN{
+		CountryCode: country,
+		CheckDigits: s[2:4],
+		BBAN:        s[4:],
+	}
+
+	pos := 0
+	for _, seg := range st {
+		part := i.BBAN[pos : pos+seg.length]
+		if !matches(part, seg.charset) {
+			// the value itself is left out, so the error can be logged without leaking the account number
+			return nil, fmt.Errorf("%w: unexpected characters at positions %d to %d", ErrInvalidFormat, 4+pos, 4+pos+seg.length-1)
+		}
+
+		switch seg.kind {
+		case bankCode:
+			i.BankCode = part
+		case branchCode:
+			i.BranchCode = part
+		case accountNumber:
+			i.AccountNumber = part
+		}
+		pos += seg.length
+	}
+
+	if mod97(i.BBAN+country+i.CheckDigits) != 1 {
+		return nil, ErrInvalidChecksum
+	}
+
+	return i, nil
+}
+
+// Validate Checks whether the string is a valid IBAN.
+func Validate(s string) error {
+	_, err := Parse(s)
+	return err
+}
+
+// ValidateChecksum Checks the length, characters and mod-97 checksum of an IBAN of any country, including the ones
+// whose national structure isn't supported.
+func ValidateChecksum(s string) error {
+	s = normalize(s)
+	if len(s) < minLength || len(s) > maxLength {
+		return fmt.Errorf("%w: IBANs have between %d and %d characters, got %d", ErrInvalidLength, minLength, maxLength, len(s))
+	}
+	if !matches(s[:2], 'a') || !matches(s[2:4], 'n') || !matches(s[4:], 'c') {
+		return fmt.Errorf("%w: must be a country code, check digits and alphanumeric characters", ErrInvalidFormat)
+	}
+	if mod97(s[4:]+s[:4]) != 1 {
+		return ErrInvalidChecksum
+	}
+
+	return nil
+}
+
+// Generate Builds a valid IBAN from the country, the national bank identifier and the account number. Numeric account
+// numbers are left padded with zeros. The bank identifier is the part of the BBAN before the account number, i.e. the
+// bank code followed by the branch code, when the country has one. It differs from the Form3 bank_id of countries
+// whose bank code comes from the BIC, e.g. the GB sort code, see GenerateWithBIC.
+// National check digits aren't computed, so for countries that have them they must be part of the given identifiers.
+func Generate(country string, bankID string, account string) (*IBAN, error) {
+	st, ok := structures[country]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedCountry, country)
+	}
+
+	bankID = normalize(bankID)
+	account = normalize(account)
+
+	accountLength := 0
+	numericAccount := true
+	for _, seg := range st {
+		if seg.kind == accountNumber {
+			accountLength += seg.length
+			numericAccount = numericAccount && seg.charset == 'n'
+		}
+	}
+	if len(account) < accountLength && numericAccount {
+		account = strings.Repeat("0", accountLength-len(account)) + account
+	}
+
+	bban := bankID + account
+	if len(bban) != st.length() {
+		return nil, fmt.Errorf("%w: %s BBANs have %d characters, got %d", ErrInvalidLength, country, st.length(), len(bban))
+	}
+
+	check := 98 - mod97(bban+country+"00")
+
+	return Parse(fmt.Sprintf("%s%02d%s", country, check, bban))
+}
+
+// GenerateWithBIC Builds a valid IBAN from the country, the BIC, the Form3 bank_id and the account number. In countries
+// whose bank code is the institution code of the BIC, e.g. GB and IE where the bank_id is the sort code, the bank code
+// is taken from the BIC. Elsewhere the BIC is ignored and it behaves as Generate.
+func GenerateWithBIC(country string, bic string, bankID string, account string) (*IBAN, error) {
+	st, ok := structures[country]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedCountry, country)
+	}
+	if st[0].kind != bankCode || st[0].length != 4 || st[0].charset != 'a' {
+		return Generate(country, bankID, account)
+	}
+
+	parsed, err := ParseBIC(bic)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.CountryCode != country {
+		return nil, fmt.Errorf("%w: BIC of %s for a %s IBAN", ErrInvalidBIC, parsed.CountryCode, country)
+	}
+
+	return Generate(country, parsed.InstitutionCode+normalize(bankID), account)
+}
+
+// String Returns the IBAN in electronic format.
+func (i IBAN) String() string {
+	return i.CountryCode + i.CheckDigits + i.BBAN
+}
+
+// PrintFormat Returns the IBAN in groups of four characters separated by spaces.
+func (i IBAN) PrintFormat() string {
+	s := i.String()
+
+	var sb strings.Builder
+	for pos := 0; pos < len(s); pos += 4 {
+		if pos > 0 {
+			sb.WriteByte(' ')
+		}
+		end := pos + 4
+		if end > len(s) {
+			end = len(s)
+		}
+		sb.WriteString(s[pos:end])
+	}
+
+	return sb.String()
+}
+
+func normalize(s string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(s), " ", ""))
+}
+
+func matches(s string, charset byte) bool {
+	for _, r := range s {
+		isDigit := r >= '0' && r <= '9'
+		isLetter := r >= 'A' && r <= 'Z'
+
+		switch {
+		case charset == 'n' && !isDigit:
+			return false
+		case charset == 'a' && !isLetter:
+			return false
+		case charset == 'c' && !isDigit && !isLetter:
+			return false
+		}
+	}
+
+	return true
+}
+
+// mod97 Computes the ISO 7064 mod 97-10 remainder, replacing letters by two digit numbers (A = 10, ..., Z = 35).
+func mod97(s string) int {
+	var digits strings.Builder
+	for _, r := range s {
+		if r >= 'A' && r <= 'Z' {
+			digits.WriteString(fmt.Sprint(r - 'A' + 10))
+		} else {
+			digits.WriteRune(r)
+		}
+	}
+
+	n, ok := new(big.Int).SetString(digits.String(), 10)
+	if !ok {
+		return -1
+	}
+
+	return int(new(big.Int).Mod(n, big.NewInt(97)).Int64())
+}
@@ -0,0 +1,140 @@
+package iban
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name          string
+		iban          string
+		expectedErr   error
+		bankCode      string
+		branchCode    string
+		accountNumber string
+	}{
+		{"Parses GB IBAN", "GB71NWBK40030212764204", nil, "NWBK", "400302", "12764204"},
+		{"Parses print format", "gb29 nwbk 6016 1331 9268 19", nil, "NWBK", "601613", "31926819"},
+		{"Parses DE IBAN", "DE89370400440532013000", nil, "37040044", "", "0532013000"},
+		{"Parses IT IBAN", "IT60X0542811101000000123456", nil, "05428", "11101", "000000123456"},
+		{"Parses BR IBAN", "BR1800360305000010009795493C1", nil, "00360305", "00001", "0009795493"},
+		{"Parses RO IBAN", "RO49AAAA1B31007593840000", nil, "AAAA", "", "1B31007593840000"},
+		{"Parses TR IBAN", "TR330006100519786457841326", nil, "00061", "", "0519786457841326"},
+		{"Parses SA IBAN", "SA0380000000608010167519", nil, "80", "", "000000608010167519"},
+		{"Parses AE IBAN", "AE070331234567890123456", nil, "033", "", "1234567890123456"},
+		{"Parses IL IBAN", "IL620108000000099999999", nil, "010", "800", "0000099999999"},
+		{"Parses QA IBAN", "QA58DOHB00001234567890ABCDEFG", nil, "DOHB", "", "00001234567890ABCDEFG"},
+		{"Parses KW IBAN", "KW81CBKU0000000000001234560101", nil, "CBKU", "", "0000000000001234560101"},
+		{"Rejects wrong checksum", "GB29NWBK60161331926818", ErrInvalidChecksum, "", "", ""},
+		{"Rejects wrong length", "GB29NWBK6016133192681", ErrInvalidLength, "", "", ""},
+		{"Rejects wrong structure", "GB29NWBK6016133192681A", ErrInvalidFormat, "", "", ""},
+		{"Rejects unsupported country", "US29NWBK60161331926819", ErrUnsupportedCountry, "", "", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			i, err := Parse(tc.iban)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatal("Expected error", tc.expectedErr, "got", err)
+			}
+			if err != nil {
+				return
+			}
+			if i.BankCode != tc.bankCode || i.BranchCode != tc.branchCode || i.AccountNumber != tc.accountNumber {
+				t.Fatal("Wrong identifiers extracted! Got", i)
+			}
+		})
+	}
+}
+
+func TestValidateChecksum(t *testing.T) {
+	testCases := []struct {
+		name        string
+		iban        string
+		expectedErr error
+	}{
+		{"Accepts IBAN of unsupported country", "MU17BOMM0101101030300200000MUR", nil},
+		{"Accepts IBAN of supported country", "GB71NWBK40030212764204", nil},
+		{"Rejects wrong checksum", "MU17BOMM0101101030300200000MUX", ErrInvalidChecksum},
+		{"Rejects too short IBAN", "MU17BOMM0101", ErrInvalidLength},
+		{"Rejects invalid characters", "MU17BOMM0101101030300200000MU-", ErrInvalidFormat},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := ValidateChecksum(tc.iban); !errors.Is(err, tc.expectedErr) {
+				t.Fatal("Expected error", tc.expectedErr, "got", err)
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		country  string
+		bankID   string
+		account  string
+		expected string
+	}{
+		{"Generates GB IBAN", "GB", "NWBK400302", "12764204", "GB71NWBK40030212764204"},
+		{"Generates DE IBAN padding the account number", "DE", "37040044", "532013000", "DE89370400440532013000"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			i, err := Generate(tc.country, tc.bankID, tc.account)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if i.String() != tc.expected {
+				t.Fatal("Expected", tc.expected, "got", i)
+			}
+		})
+	}
+
+	if _, err := Generate("GB", "NWBK", "12764204"); !errors.Is(err, ErrInvalidLength) {
+		t.Fatal("Expected length error, got", err)
+	}
+}
+
+func TestGenerateWithBIC(t *testing.T) {
+	testCases := []struct {
+		name       string
+		country    string
+		bic        string
+		bankID     string
+		account    string
+		expected   string
+		shouldFail bool
+	}{
+		{"Generates GB IBAN from the sort code", "GB", "NWBKGB42", "400302", "12764204", "GB71NWBK40030212764204", false},
+		{"Generates DE IBAN ignoring the BIC", "DE", "", "37040044", "532013000", "DE89370400440532013000", false},
+		{"Fails with invalid BIC", "GB", "NWBK", "400302", "12764204", "", true},
+		{"Fails with BIC of another country", "GB", "NWBKIE2D", "400302", "12764204", "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			i, err := GenerateWithBIC(tc.country, tc.bic, tc.bankID, tc.account)
+			if (err != nil) != tc.shouldFail {
+				t.Fatal("Fail condition didn't match! Got", err, "should've been", tc.shouldFail)
+			}
+			if err == nil && i.String() != tc.expected {
+				t.Fatal("Expected", tc.expected, "got", i)
+			}
+		})
+	}
+}
+
+func TestIBAN_PrintFormat(t *testing.T) {
+	i, err := Parse("GB71NWBK40030212764204")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if i.PrintFormat() != "GB71 NWBK 4003 0212 7642 04" {
+		t.Fatal("Wrong print format! Got", i.PrintFormat())
+	}
+}
@@ -0,0 +1,117 @@
+package organisation_api
+
+import (
+	"context"
+	"net/url"
+)
+
+// AccountIterator Lazily walks every account matching the list options, following the links.next URLs.
+// Use it like bufio.Scanner: call Next until it returns false, then check Err.
+type AccountIterator struct {
+	c       *OrganisationApiClient
+	ctx     context.Context
+	next    *url.URL
+	page    []AccountData
+	idx     int
+	current *AccountData
+	err     error
+	// last Error reported once the accounts are consumed, see NewAccountIterator.
+	last error
+}
+
+// NewAccountIterator Returns an iterator over the given accounts, reporting err once they are consumed, e.g. to stub
+// AccountsService.Iterate in tests.
+func NewAccountIterator(accounts []AccountData, err error) *AccountIterator {
+	return &AccountIterator{page: append([]AccountData(nil), accounts...), last: err}
+}
+
+// IterateAccounts Returns an iterator over the accounts matching the options. Uses defaultContext as the context.
+func (c *OrganisationApiClient) IterateAccounts(opts ListOptions) *AccountIterator {
+	return c.IterateAccountsWithContext(opts, defaultContext)
+}
+
+// IterateAccountsWithContext Returns an iterator over the accounts matching the options with the given context.
+// No request is made until Next is called.
+func (c *OrganisationApiClient) IterateAccountsWithContext(opts ListOptions, ctx context.Context) *AccountIterator {
+	return c.iterateAccounts(ctx, opts)
+}
+
+func (c *OrganisationApiClient) iterateAccounts(ctx context.Context, opts ListOptions) *AccountIterator {
+	it := &AccountIterator{c: c, ctx: ctx}
+
+	requestUrl, err := buildAccountsUrl(c)
+	if err != nil {
+		it.err = err
+		return it
+	}
+	requestUrl.RawQuery = encodeListOptions(opts)
+	it.next = requestUrl
+
+	return it
+}
+
+// Next Advances the iterator to the next account, fetching the next page when needed.
+// Returns false when there are no more accounts or an error happened.
+func (it *AccountIterator) Next() bool {
+	for it.err == nil {
+		if it.idx < len(it.page) {
+			it.current = &it.page[it.idx]
+			it.idx++
+			return true
+		}
+
+		if it.next == nil {
+			it.err = it.last
+			break
+		}
+
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			break
+		}
+
+		it.fetchPage()
+	}
+
+	it.current = nil
+	return false
+}
+
+// Account Returns the account the iterator is currently positioned on.
+func (it *AccountIterator) Account() *AccountData {
+	return it.current
+}
+
+// Err Returns the first error found while iterating, if any.
+func (it *AccountIterator) Err() error {
+	return it.err
+}
+
+func (it *AccountIterator) fetchPage() {
+	current := it.next
+	resp, err := it.c.listAccountsPage(it.ctx, *current)
+	if err != nil {
+		it.err = err
+		return
+	}
+
+	it.page = resp.Data
+	it.idx = 0
+	it.next = nil
+
+	// an empty page ends the iteration even if the server keeps handing out next links
+	if len(resp.Data) == 0 || resp.Links == nil || resp.Links.Next == "" {
+		return
+	}
+
+	nextUrl, err := url.Parse(resp.Links.Next)
+	if err != nil {
+		it.err = err
+		return
+	}
+
+	nextUrl = current.ResolveReference(nextUrl)
+	if nextUrl.String() != current.String() {
+		it.next = nextUrl
+	}
+}
@@ -0,0 +1,117 @@
+//go:build !integration
+// +build !integration
+
+package organisation_api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func pagedTransport(t *testing.T, pages int, requests *int) roundTripAux {
+	return func(r *http.Request) (*http.Response, error) {
+		*requests++
+
+		page := 0
+		if n := r.URL.Query().Get("page[number]"); n != "" {
+			if _, err := fmt.Sscan(n, &page); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		holder := accountListHolder{Links: &Links{}}
+		for i := 0; i < 2; i++ {
+			holder.Data = append(holder.Data, AccountData{ID: fmt.Sprintf("account-%d-%d", page, i)})
+		}
+		if page+1 < pages {
+			holder.Links.Next = fmt.Sprintf("/v1/organisation/accounts?page[number]=%d&page[size]=2", page+1)
+		}
+
+		j, err := json.Marshal(holder)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(string(j))),
+		}, nil
+	}
+}
+
+func TestAccountIterator_Next(t *testing.T) {
+	requests := 0
+	c := &OrganisationApiClient{
+		Client: &http.Client{Transport: pagedTransport(t, 3, &requests)},
+		ClientConfig: &ClientConfig{
+			RootUrl:        defaultRootUrl,
+			DebugLog:       nil,
+			IsDebugEnabled: false,
+		},
+	}
+
+	it := c.IterateAccounts(ListOptions{PageSize: 2})
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Account().ID)
+	}
+	if it.Err() != nil {
+		t.Fatal("Got iterator error", it.Err())
+	}
+	if len(ids) != 6 || ids[0] != "account-0-0" || ids[5] != "account-2-1" {
+		t.Fatal("Wrong accounts iterated! Got", ids)
+	}
+	if requests != 3 {
+		t.Fatal("Expected 3 requests, got", requests)
+	}
+}
+
+func TestAccountIterator_NextWithCancelledContext(t *testing.T) {
+	requests := 0
+	c := &OrganisationApiClient{
+		Client: &http.Client{Transport: pagedTransport(t, 3, &requests)},
+		ClientConfig: &ClientConfig{
+			RootUrl:        defaultRootUrl,
+			DebugLog:       nil,
+			IsDebugEnabled: false,
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	it := c.IterateAccountsWithContext(ListOptions{PageSize: 2}, ctx)
+
+	count := 0
+	for it.Next() {
+		count++
+		cancel()
+	}
+	if it.Err() != context.Canceled {
+		t.Fatal("Expected cancellation error, got", it.Err())
+	}
+	if count != 2 || requests != 1 {
+		t.Fatal("Iteration didn't stop after the first page! Got", count, "accounts and", requests, "requests")
+	}
+}
+
+func TestNewAccountIterator(t *testing.T) {
+	fail := errors.New("page fetch failed")
+	it := NewAccountIterator([]AccountData{{ID: "1"}, {ID: "2"}}, fail)
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Account().ID)
+	}
+	if len(ids) != 2 || ids[1] != "2" {
+		t.Fatal("Wrong accounts iterated! Got", ids)
+	}
+	if it.Err() != fail || it.Next() {
+		t.Fatal("Expected the error once the accounts are consumed, got", it.Err())
+	}
+}
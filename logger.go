@@ -0,0 +1,181 @@
+package organisation_api
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Level Severity of a log event.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	}
+
+	return "LEVEL(" + strconv.Itoa(int(l)) + ")"
+}
+
+// Logger Leveled logger taking alternating key/value pairs after the message. Its method set is the one of
+// *slog.Logger, which can be used as is.
+type Logger interface {
+	Debug(msg string, keysAndValues ...interface{})
+	Info(msg string, keysAndValues ...interface{})
+	Warn(msg string, keysAndValues ...interface{})
+	Error(msg string, keysAndValues ...interface{})
+}
+
+// LoggerFunc Adapter to use a single function as a Logger, e.g. to bridge other structured logging libraries.
+type LoggerFunc func(level Level, msg string, keysAndValues ...interface{})
+
+func (f LoggerFunc) Debug(msg string, keysAndValues ...interface{}) {
+	f(LevelDebug, msg, keysAndValues...)
+}
+
+func (f LoggerFunc) Info(msg string, keysAndValues ...interface{}) {
+	f(LevelInfo, msg, keysAndValues...)
+}
+
+func (f LoggerFunc) Warn(msg string, keysAndValues ...interface{}) {
+	f(LevelWarn, msg, keysAndValues...)
+}
+
+func (f LoggerFunc) Error(msg string, keysAndValues ...interface{}) {
+	f(LevelError, msg, keysAndValues...)
+}
+
+// NewStdLogger Adapts a standard library logger, writing events of at least minLevel in logfmt, e.g.
+// `level=INFO msg="request end" method=GET status=200`.
+func NewStdLogger(logger *log.Logger, minLevel Level) Logger {
+	return LoggerFunc(func(level Level, msg string, keysAndValues ...interface{}) {
+		if level < minLevel {
+			return
+		}
+
+		logger.Println(formatLogfmt(level, msg, keysAndValues))
+	})
+}
+
+var nopLogger Logger = LoggerFunc(func(Level, string, ...interface{}) {})
+
+// logger Returns the logger of the client config. A legacy DebugLog is adapted, logging debug events only when
+// IsDebugEnabled is set.
+func (c *OrganisationApiClient) logger() Logger {
+	if c.ClientConfig.Logger != nil {
+		return c.ClientConfig.Logger
+	}
+
+	if c.ClientConfig.DebugLog != nil {
+		minLevel := LevelInfo
+		if c.ClientConfig.IsDebugEnabled {
+			minLevel = LevelDebug
+		}
+
+		// rebuilt only when the config changed since the last call
+		if cached, ok := c.stdLogger.Load().(*stdLogger); ok && cached.out == c.ClientConfig.DebugLog && cached.minLevel == minLevel {
+			return cached.Logger
+		}
+		cached := &stdLogger{Logger: NewStdLogger(c.ClientConfig.DebugLog, minLevel), out: c.ClientConfig.DebugLog, minLevel: minLevel}
+		c.stdLogger.Store(cached)
+		return cached.Logger
+	}
+
+	return nopLogger
+}
+
+// stdLogger Adapter of a DebugLog cached by the client, along with the settings it was built with.
+type stdLogger struct {
+	Logger
+	out      *log.Logger
+	minLevel Level
+}
+
+// operation Account operation a request is sent for, annotating its log events.
+type operation struct {
+	name      string
+	accountID string
+}
+
+// fields Key/value pairs describing the request of the operation, followed by extra ones.
+func (c *OrganisationApiClient) fields(op operation, req *http.Request, extra ...interface{}) []interface{} {
+	kv := []interface{}{"operation", op.name, "method", req.Method, "url", c.redactor().RedactURL(req.URL)}
+	if op.accountID != "" {
+		kv = append(kv, "account_id", op.accountID)
+	}
+	if id := req.Header.Get(requestIDHeader); id != "" {
+		kv = append(kv, "request_id", id)
+	}
+
+	return append(kv, extra...)
+}
+
+// logResult Logs the end or the error event of the operation's request.
+func (c *OrganisationApiClient) logResult(op operation, req *http.Request, resp *http.Response, err error, duration time.Duration) {
+	if err != nil {
+		c.logger().Error("request error", c.fields(op, req, "duration", duration, "error", c.redactor().redactError(err))...)
+		return
+	}
+
+	kv := c.fields(op, req, "status", resp.StatusCode, "duration", duration)
+	if id := resp.Header.Get(requestIDHeader); id != "" && req.Header.Get(requestIDHeader) == "" {
+		kv = append(kv, "request_id", id)
+	}
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		c.logger().Warn("request end", kv...)
+		return
+	}
+	c.logger().Debug("request end", kv...)
+}
+
+// logError Logs the error event of an operation failing outside of the request round trip.
+func (c *OrganisationApiClient) logError(op operation, err error) {
+	kv := []interface{}{"operation", op.name}
+	if op.accountID != "" {
+		kv = append(kv, "account_id", op.accountID)
+	}
+
+	c.logger().Error("request error", append(kv, "error", c.redactor().redactError(err))...)
+}
+
+func formatLogfmt(level Level, msg string, keysAndValues []interface{}) string {
+	b := &strings.Builder{}
+	b.WriteString("level=" + level.String() + " msg=" + logfmtValue(msg))
+
+	for i := 0; i < len(keysAndValues); i += 2 {
+		if i+1 == len(keysAndValues) {
+			b.WriteString(" !BADKEY=" + logfmtValue(keysAndValues[i]))
+			break
+		}
+		b.WriteString(" " + fmt.Sprint(keysAndValues[i]) + "=" + logfmtValue(keysAndValues[i+1]))
+	}
+
+	return b.String()
+}
+
+func logfmtValue(v interface{}) string {
+	s := fmt.Sprint(v)
+	if s == "" || strings.ContainsAny(s, " \"=\t\n") {
+		return strconv.Quote(s)
+	}
+
+	return s
+}
@@ -0,0 +1,194 @@
+//go:build !integration
+// +build !integration
+
+package organisation_api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type logEvent struct {
+	level  Level
+	msg    string
+	fields map[string]interface{}
+}
+
+func recordingLogger(events *[]logEvent) Logger {
+	return LoggerFunc(func(level Level, msg string, keysAndValues ...interface{}) {
+		fields := map[string]interface{}{}
+		for i := 0; i+1 < len(keysAndValues); i += 2 {
+			fields[keysAndValues[i].(string)] = keysAndValues[i+1]
+		}
+		*events = append(*events, logEvent{level, msg, fields})
+	})
+}
+
+func TestLogger_requestEvents(t *testing.T) {
+	var events []logEvent
+	c := &OrganisationApiClient{
+		Client: &http.Client{
+			Transport: roundTripAux(func(r *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusNotFound,
+					Header:     http.Header{"X-Request-Id": []string{"request-1"}},
+					Body:       ioutil.NopCloser(strings.NewReader(`{"error_message":"not found"}`)),
+				}, nil
+			}),
+		},
+		ClientConfig: &ClientConfig{
+			RootUrl: defaultRootUrl,
+			Logger:  recordingLogger(&events),
+		},
+	}
+
+	if _, err := c.FetchAccount(mockAccountData.ID); err == nil {
+		t.Fatal("Expected not found error")
+	}
+
+	if len(events) < 2 || events[0].msg != "request start" || events[1].msg != "request end" {
+		t.Fatal("Expected request start and end events, got", events)
+	}
+
+	end := events[1]
+	expected := map[string]interface{}{
+		"operation":  "FetchAccount",
+		"method":     http.MethodGet,
+		"account_id": mockAccountData.ID,
+		"status":     http.StatusNotFound,
+		"request_id": "request-1",
+	}
+	for k, v := range expected {
+		if end.fields[k] != v {
+			t.Fatal("Expected", k, v, "got", end.fields[k])
+		}
+	}
+	if _, ok := end.fields["duration"]; !ok {
+		t.Fatal("Missing duration in", end.fields)
+	}
+}
+
+func TestLogger_requestErrorEvent(t *testing.T) {
+	var events []logEvent
+	c := &OrganisationApiClient{
+		Client: &http.Client{
+			Transport: roundTripAux(func(r *http.Request) (*http.Response, error) {
+				return nil, errors.New("connection refused")
+			}),
+		},
+		ClientConfig: &ClientConfig{
+			RootUrl: defaultRootUrl,
+			Logger:  recordingLogger(&events),
+		},
+	}
+
+	if _, err := c.DeleteAccount(mockAccountData.ID, 0); err == nil {
+		t.Fatal("Expected transport error")
+	}
+
+	last := events[len(events)-1]
+	if last.level != LevelError || last.msg != "request error" || last.fields["operation"] != "DeleteAccount" {
+		t.Fatal("Expected request error event, got", last)
+	}
+}
+
+func TestNewStdLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := NewStdLogger(log.New(buf, "", 0), LevelInfo)
+
+	logger.Debug("hidden")
+	logger.Info("request end", "method", "GET", "url", "http://localhost/a b", "status", 200, "dangling")
+
+	expected := `level=INFO msg="request end" method=GET url="http://localhost/a b" status=200 !BADKEY=dangling` + "\n"
+	if buf.String() != expected {
+		t.Fatal("Expected", expected, "got", buf.String())
+	}
+}
+
+func TestLogger_legacyDebugLog(t *testing.T) {
+	buf := &bytes.Buffer{}
+	c := &OrganisationApiClient{
+		ClientConfig: &ClientConfig{DebugLog: log.New(buf, "", 0)},
+	}
+
+	c.logger().Debug("received body")
+	c.logger().Warn("request retry")
+	if strings.Contains(buf.String(), "received body") || !strings.Contains(buf.String(), "request retry") {
+		t.Fatal("Debug events should only be logged with IsDebugEnabled, got", buf.String())
+	}
+
+	c.ClientConfig.IsDebugEnabled = true
+	c.logger().Debug("received body")
+	if !strings.Contains(buf.String(), "received body") {
+		t.Fatal("Expected debug event, got", buf.String())
+	}
+}
+
+func TestLogger_legacyDebugLogIsCached(t *testing.T) {
+	c := &OrganisationApiClient{
+		ClientConfig: &ClientConfig{DebugLog: log.New(ioutil.Discard, "", 0)},
+	}
+
+	c.logger()
+	first := c.stdLogger.Load()
+	c.logger()
+	if c.stdLogger.Load() != first {
+		t.Fatal("Expected the adapter to be reused")
+	}
+
+	c.ClientConfig.IsDebugEnabled = true
+	c.logger()
+	if c.stdLogger.Load() == first {
+		t.Fatal("Expected the adapter to be rebuilt once the config changed")
+	}
+}
+
+func TestLogger_requestBodyReadLazily(t *testing.T) {
+	var events []logEvent
+	c := &OrganisationApiClient{
+		Client: &http.Client{
+			Transport: roundTripAux(func(r *http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody}, nil
+			}),
+		},
+		ClientConfig: &ClientConfig{RootUrl: defaultRootUrl},
+	}
+
+	reads := 0
+	payload := `{"data":{"attributes":{"iban":"GB11NWBK40030041426819"}}}`
+	req, err := http.NewRequest(http.MethodPost, defaultRootUrl.String(), strings.NewReader(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.GetBody = func() (io.ReadCloser, error) {
+		reads++
+		return ioutil.NopCloser(strings.NewReader(payload)), nil
+	}
+
+	if _, err := c.do(req, operation{name: "CreateAccount"}); err != nil {
+		t.Fatal(err)
+	}
+	if reads != 0 {
+		t.Fatal("Expected the body not to be read without a logger, got", reads, "reads")
+	}
+
+	c.ClientConfig.Logger = recordingLogger(&events)
+	if _, err := c.do(req, operation{name: "CreateAccount"}); err != nil {
+		t.Fatal(err)
+	}
+	if reads != 0 {
+		t.Fatal("Expected the body to be read only when formatted, got", reads, "reads")
+	}
+
+	body := fmt.Sprint(events[0].fields["body"])
+	if reads != 1 || strings.Contains(body, "GB11NWBK40030041426819") || !strings.Contains(body, "iban") {
+		t.Fatal("Expected the redacted body, got", body, "after", reads, "reads")
+	}
+}
@@ -0,0 +1,251 @@
+package organisation_api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const metricsNamespace = "organisation_api_"
+
+// DefaultLatencyBuckets Upper bounds, in seconds, of the request duration histogram.
+var DefaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// DefaultSizeBuckets Upper bounds, in bytes, of the payload size histograms.
+var DefaultSizeBuckets = []float64{256, 1024, 4096, 16384, 65536, 262144, 1048576}
+
+// Metrics Collects the metrics of the clients it is given to, per operation, and serves them in the Prometheus text
+// exposition format. It is safe for concurrent use and can be shared by several clients.
+type Metrics struct {
+	mu             sync.Mutex
+	latencyBuckets []float64
+	requests       map[[2]string]uint64
+	retries        map[string]uint64
+	inFlight       map[string]int64
+	latency        map[string]*histogram
+	requestSize    map[string]*histogram
+	responseSize   map[string]*histogram
+}
+
+// NewMetrics Builds an empty collector, using the given latency buckets or DefaultLatencyBuckets when none.
+func NewMetrics(latencyBuckets ...float64) *Metrics {
+	if len(latencyBuckets) == 0 {
+		latencyBuckets = DefaultLatencyBuckets
+	}
+
+	buckets := append([]float64(nil), latencyBuckets...)
+	sort.Float64s(buckets)
+
+	return &Metrics{
+		latencyBuckets: buckets,
+		requests:       map[[2]string]uint64{},
+		retries:        map[string]uint64{},
+		inFlight:       map[string]int64{},
+		latency:        map[string]*histogram{},
+		requestSize:    map[string]*histogram{},
+		responseSize:   map[string]*histogram{},
+	}
+}
+
+// The recording methods are no-ops on a nil collector, so clients without metrics don't need to check.
+
+func (m *Metrics) startRequest(op string, req *http.Request) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.inFlight[op]++
+	if req.ContentLength > 0 {
+		observe(m.requestSize, op, DefaultSizeBuckets, float64(req.ContentLength))
+	}
+}
+
+// endRequest Records the outcome of the request. The size of the response is recorded once its body is closed.
+func (m *Metrics) endRequest(op string, resp *http.Response, err error, duration time.Duration) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.inFlight[op]--
+	m.requests[[2]string{op, statusClass(resp, err)}]++
+	observe(m.latency, op, m.latencyBuckets, duration.Seconds())
+
+	if resp != nil {
+		resp.Body = &countingBody{ReadCloser: resp.Body, onClose: func(n int64) {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			observe(m.responseSize, op, DefaultSizeBuckets, float64(n))
+		}}
+	}
+}
+
+func (m *Metrics) retried(op string) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.retries[op]++
+}
+
+// ServeHTTP Writes the metrics in the Prometheus text exposition format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = m.WriteTo(w)
+}
+
+// WriteTo Writes the metrics in the Prometheus text exposition format.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b := &strings.Builder{}
+
+	writeHeader(b, "requests_total", "counter", "Requests sent, by operation and status class.")
+	keys := make([][2]string, 0, len(m.requests))
+	for k := range m.requests {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i][0] < keys[j][0] || keys[i][0] == keys[j][0] && keys[i][1] < keys[j][1]
+	})
+	for _, k := range keys {
+		writeSample(b, "requests_total", labels("operation", k[0], "status_class", k[1]), float64(m.requests[k]))
+	}
+
+	writeHeader(b, "retries_total", "counter", "Attempts retried, by operation.")
+	for _, op := range sortedKeys(m.retries) {
+		writeSample(b, "retries_total", labels("operation", op), float64(m.retries[op]))
+	}
+
+	writeHeader(b, "in_flight_requests", "gauge", "Requests in progress, by operation.")
+	for _, op := range sortedKeys(m.inFlight) {
+		writeSample(b, "in_flight_requests", labels("operation", op), float64(m.inFlight[op]))
+	}
+
+	writeHistograms(b, "request_duration_seconds", "Duration of the requests including retries, by operation.", m.latency)
+	writeHistograms(b, "request_size_bytes", "Size of the request payloads, by operation.", m.requestSize)
+	writeHistograms(b, "response_size_bytes", "Size of the response payloads, by operation.", m.responseSize)
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// histogram Cumulative histogram, counts[i] holding the observations lower or equal to buckets[i].
+type histogram struct {
+	buckets []float64
+	counts  []uint64
+	count   uint64
+	sum     float64
+}
+
+func observe(histograms map[string]*histogram, op string, buckets []float64, v float64) {
+	h, ok := histograms[op]
+	if !ok {
+		h = &histogram{buckets: buckets, counts: make([]uint64, len(buckets))}
+		histograms[op] = h
+	}
+
+	for i, upper := range h.buckets {
+		if v <= upper {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += v
+}
+
+func statusClass(resp *http.Response, err error) string {
+	if err != nil || resp == nil {
+		return "error"
+	}
+
+	return strconv.Itoa(resp.StatusCode/100) + "xx"
+}
+
+// countingBody Response body reporting the number of bytes read when closed.
+type countingBody struct {
+	io.ReadCloser
+	n       int64
+	onClose func(n int64)
+	once    sync.Once
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	return n, err
+}
+
+func (b *countingBody) Close() error {
+	b.once.Do(func() { b.onClose(b.n) })
+	return b.ReadCloser.Close()
+}
+
+func writeHeader(b *strings.Builder, name string, metricType string, help string) {
+	fmt.Fprintf(b, "# HELP %s%s %s\n# TYPE %s%s %s\n", metricsNamespace, name, help, metricsNamespace, name, metricType)
+}
+
+func writeSample(b *strings.Builder, name string, labels string, v float64) {
+	fmt.Fprintf(b, "%s%s{%s} %s\n", metricsNamespace, name, labels, strconv.FormatFloat(v, 'g', -1, 64))
+}
+
+func writeHistograms(b *strings.Builder, name string, help string, histograms map[string]*histogram) {
+	writeHeader(b, name, "histogram", help)
+
+	for _, op := range sortedKeys(histograms) {
+		h := histograms[op]
+		for i, upper := range h.buckets {
+			le := strconv.FormatFloat(upper, 'g', -1, 64)
+			writeSample(b, name+"_bucket", labels("operation", op, "le", le), float64(h.counts[i]))
+		}
+		writeSample(b, name+"_bucket", labels("operation", op, "le", "+Inf"), float64(h.count))
+		writeSample(b, name+"_sum", labels("operation", op), h.sum)
+		writeSample(b, name+"_count", labels("operation", op), float64(h.count))
+	}
+}
+
+// labels Formats the label pairs, escaping the values as required by the exposition format.
+func labels(keysAndValues ...string) string {
+	pairs := make([]string, 0, len(keysAndValues)/2)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		v := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(keysAndValues[i+1])
+		pairs = append(pairs, keysAndValues[i]+`="`+v+`"`)
+	}
+
+	return strings.Join(pairs, ",")
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch typed := m.(type) {
+	case map[string]uint64:
+		for k := range typed {
+			keys = append(keys, k)
+		}
+	case map[string]int64:
+		for k := range typed {
+			keys = append(keys, k)
+		}
+	case map[string]*histogram:
+		for k := range typed {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
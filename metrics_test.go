@@ -0,0 +1,113 @@
+//go:build !integration
+// +build !integration
+
+package organisation_api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetrics_recordsOperations(t *testing.T) {
+	body, err := json.Marshal(dataHolder{Data: mockAccountData})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			attempts++
+			if attempts == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write(body)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	metrics := NewMetrics()
+	c, err := NewClient(
+		WithBaseURL(ts.URL+"/v1/organisation/"),
+		WithMetrics(metrics),
+		WithRetryPolicy(&RetryPolicy{
+			MaxAttempts:       2,
+			BaseDelay:         time.Millisecond,
+			RetryableStatuses: map[int]bool{http.StatusServiceUnavailable: true},
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.FetchAccount(mockAccountData.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.DeleteAccount(mockAccountData.ID, 0); err == nil {
+		t.Fatal("Expected not found")
+	}
+
+	rec := httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain; version=0.0.4") {
+		t.Fatal("Wrong content type", rec.Header().Get("Content-Type"))
+	}
+
+	exposition := rec.Body.String()
+	expected := []string{
+		"# TYPE organisation_api_requests_total counter",
+		`organisation_api_requests_total{operation="FetchAccount",status_class="2xx"} 1`,
+		`organisation_api_requests_total{operation="DeleteAccount",status_class="4xx"} 1`,
+		`organisation_api_retries_total{operation="FetchAccount"} 1`,
+		`organisation_api_in_flight_requests{operation="FetchAccount"} 0`,
+		"# TYPE organisation_api_request_duration_seconds histogram",
+		`organisation_api_request_duration_seconds_bucket{operation="FetchAccount",le="+Inf"} 1`,
+		`organisation_api_request_duration_seconds_count{operation="DeleteAccount"} 1`,
+		`organisation_api_response_size_bytes_sum{operation="FetchAccount"} ` + strconv.Itoa(len(body)),
+	}
+	for _, line := range expected {
+		if !strings.Contains(exposition, line+"\n") {
+			t.Fatal("Missing", line, "in\n", exposition)
+		}
+	}
+}
+
+func TestMetrics_requestSize(t *testing.T) {
+	metrics := NewMetrics(1, 0.1)
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("payload"))
+
+	metrics.startRequest("CreateAccount", req)
+	metrics.endRequest("CreateAccount", nil, http.ErrHandlerTimeout, 500*time.Millisecond)
+
+	b := &strings.Builder{}
+	if _, err := metrics.WriteTo(b); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		`organisation_api_requests_total{operation="CreateAccount",status_class="error"} 1`,
+		`organisation_api_request_duration_seconds_bucket{operation="CreateAccount",le="0.1"} 0`,
+		`organisation_api_request_duration_seconds_bucket{operation="CreateAccount",le="1"} 1`,
+		`organisation_api_request_size_bytes_bucket{operation="CreateAccount",le="256"} 1`,
+		`organisation_api_request_size_bytes_sum{operation="CreateAccount"} 7`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(b.String(), line+"\n") {
+			t.Fatal("Missing", line, "in\n", b.String())
+		}
+	}
+
+	var nilMetrics *Metrics
+	nilMetrics.startRequest("CreateAccount", req)
+	nilMetrics.retried("CreateAccount")
+}
@@ -0,0 +1,116 @@
+package organisation_api
+
+import (
+	"encoding/hex"
+	"net/http"
+	"time"
+)
+
+// Middleware Wraps a RoundTripper to add cross-cutting behaviour to every request sent by the client.
+// Like any RoundTripper, a middleware must not modify the request it is given, but a clone of it.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc Adapter to use ordinary functions as a RoundTripper.
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip Calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Chain Wraps base with the middlewares. The first middleware is the outermost, seeing the request first and the
+// response last. A nil base means http.DefaultTransport.
+func Chain(base http.RoundTripper, middlewares ...Middleware) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		base = middlewares[i](base)
+	}
+
+	return base
+}
+
+// RequestIDMiddleware Sets the X-Request-Id header on requests that don't have one, using generate or random hex IDs
+// when nil. The ID is set on a clone of every attempt, unknown to the client: use WithRequestID for it to be shared by
+// the retries and show in the log events and spans of the client.
+func RequestIDMiddleware(generate func() string) Middleware {
+	if generate == nil {
+		generate = randomRequestID
+	}
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get(requestIDHeader) != "" {
+				return next.RoundTrip(req)
+			}
+
+			r := req.Clone(req.Context())
+			r.Header.Set(requestIDHeader, generate())
+			return next.RoundTrip(r)
+		})
+	}
+}
+
+// UserAgentMiddleware Sets the User-Agent header of every request.
+func UserAgentMiddleware(userAgent string) Middleware {
+	return HeaderMiddleware(http.Header{"User-Agent": []string{userAgent}})
+}
+
+// HeaderMiddleware Sets the given headers on every request, replacing the values already set.
+func HeaderMiddleware(headers http.Header) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			r := req.Clone(req.Context())
+			for k, values := range headers {
+				r.Header.Del(k)
+				for _, v := range values {
+					r.Header.Add(k, v)
+				}
+			}
+			return next.RoundTrip(r)
+		})
+	}
+}
+
+// TimingMiddleware Calls observe with the outcome and the duration of every request.
+func TimingMiddleware(observe func(req *http.Request, resp *http.Response, err error, duration time.Duration)) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			observe(req, resp, err, time.Since(start))
+			return resp, err
+		})
+	}
+}
+
+// LoggingMiddleware Logs every request sent, including each retry, with its response status and duration. Filters on
+// personal data are masked from the URLs by redactor, DefaultRedactor when nil, which should be the one given to
+// WithRedactor.
+func LoggingMiddleware(logger Logger, redactor *Redactor) Middleware {
+	if redactor == nil {
+		redactor = DefaultRedactor
+	}
+
+	return TimingMiddleware(func(req *http.Request, resp *http.Response, err error, duration time.Duration) {
+		kv := []interface{}{"method", req.Method, "url", redactor.RedactURL(req.URL), "duration", duration}
+		if id := req.Header.Get(requestIDHeader); id != "" {
+			kv = append(kv, "request_id", id)
+		}
+
+		if err != nil {
+			logger.Error("round trip error", append(kv, "error", redactor.redactError(err))...)
+			return
+		}
+		logger.Info("round trip", append(kv, "status", resp.StatusCode)...)
+	})
+}
+
+func randomRequestID() string {
+	b := make([]byte, 16)
+	randomBytes(b)
+
+	return hex.EncodeToString(b)
+}
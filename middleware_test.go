@@ -0,0 +1,178 @@
+//go:build !integration
+// +build !integration
+
+package organisation_api
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func recordingMiddleware(name string, calls *[]string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			*calls = append(*calls, name+" before")
+			resp, err := next.RoundTrip(req)
+			*calls = append(*calls, name+" after")
+			return resp, err
+		})
+	}
+}
+
+func TestChain_order(t *testing.T) {
+	var calls []string
+	base := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls = append(calls, "base")
+		return okResponse(), nil
+	})
+
+	rt := Chain(base, recordingMiddleware("first", &calls), recordingMiddleware("second", &calls))
+	req, err := http.NewRequest(http.MethodGet, "http://localhost/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "first before,second before,base,second after,first after"
+	if strings.Join(calls, ",") != expected {
+		t.Fatal("Expected calls", expected, "got", calls)
+	}
+}
+
+func TestNewClient_WithMiddleware(t *testing.T) {
+	var sent *http.Request
+	base := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		sent = req
+		return okResponse(), nil
+	})
+
+	var observed time.Duration
+	buf := &bytes.Buffer{}
+	c, err := NewClient(
+		WithHTTPClient(&http.Client{Transport: base}),
+		WithMiddleware(
+			RequestIDMiddleware(func() string { return "request-1" }),
+			UserAgentMiddleware("platform/1.0"),
+			HeaderMiddleware(http.Header{"X-Tenant": []string{"tenant-1"}}),
+			TimingMiddleware(func(req *http.Request, resp *http.Response, err error, d time.Duration) {
+				observed = d
+			}),
+			LoggingMiddleware(NewStdLogger(log.New(buf, "", 0), LevelInfo), nil),
+		),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.DeleteAccount(mockAccountData.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if sent.Header.Get("X-Request-Id") != "request-1" || sent.Header.Get("User-Agent") != "platform/1.0" ||
+		sent.Header.Get("X-Tenant") != "tenant-1" {
+		t.Fatal("Headers weren't set! Got", sent.Header)
+	}
+	if observed <= 0 {
+		t.Fatal("Duration wasn't observed")
+	}
+	if !strings.Contains(buf.String(), "DELETE") || !strings.Contains(buf.String(), "request-1") {
+		t.Fatal("Request wasn't logged! Got", buf.String())
+	}
+
+	if _, err := NewClient(WithMiddleware(nil)); err == nil {
+		t.Fatal("Expected nil middleware error")
+	}
+}
+
+func TestRequestIDMiddleware_keepsExistingID(t *testing.T) {
+	var sent *http.Request
+	rt := Chain(RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		sent = req
+		return okResponse(), nil
+	}), RequestIDMiddleware(nil))
+
+	req, err := http.NewRequest(http.MethodGet, "http://localhost/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Request-Id", "caller-id")
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+	if sent.Header.Get("X-Request-Id") != "caller-id" {
+		t.Fatal("Caller's request ID was replaced! Got", sent.Header.Get("X-Request-Id"))
+	}
+
+	req.Header.Del("X-Request-Id")
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+	if len(sent.Header.Get("X-Request-Id")) != 32 || req.Header.Get("X-Request-Id") != "" {
+		t.Fatal("Expected generated request ID on a clone, got", sent.Header.Get("X-Request-Id"))
+	}
+}
+
+func TestNewClient_WithRequestID(t *testing.T) {
+	var sent []string
+	var events []logEvent
+	recorder := NewSpanRecorder()
+	c, err := NewClient(
+		WithLogger(recordingLogger(&events)),
+		WithTracer(recorder),
+		WithRequestID(func() string { return "request-" + strconv.Itoa(len(sent)) }),
+		WithRetryPolicy(&RetryPolicy{MaxAttempts: 2, RetryableStatuses: DefaultRetryPolicy.RetryableStatuses}),
+		WithHTTPClient(&http.Client{Transport: RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			sent = append(sent, req.Header.Get("X-Request-Id"))
+			if len(sent) == 1 {
+				return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody}, nil
+			}
+			return okResponse(), nil
+		})}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.DeleteAccount(mockAccountData.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sent) != 2 || sent[0] != "request-0" || sent[1] != "request-0" {
+		t.Fatal("Expected the retry to keep the request ID, got", sent)
+	}
+	for _, event := range events {
+		if event.fields["request_id"] != "request-0" {
+			t.Fatal("Expected the request ID in the", event.msg, "event, got", event.fields)
+		}
+	}
+	span := findSpan(recorder.Spans(), "organisation_api.DeleteAccount")
+	if span == nil || span.Attributes["http.request_id"] != "request-0" {
+		t.Fatal("Expected the request ID in the span, got", span)
+	}
+}
+
+func TestLoggingMiddleware_redactor(t *testing.T) {
+	var events []logEvent
+	rt := Chain(RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return okResponse(), nil
+	}), LoggingMiddleware(recordingLogger(&events), &Redactor{Fields: []string{"iban"}, Mask: KeepLast(4)}))
+
+	req, err := http.NewRequest(http.MethodGet, "http://localhost/v1/organisation/accounts?filter[iban]=GB71NWBK40030212764204", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+
+	if url := events[0].fields["url"].(string); strings.Contains(url, "40030212764204") || !strings.Contains(url, "4204") {
+		t.Fatal("Expected the configured redactor to mask the url, got", url)
+	}
+}
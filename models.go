@@ -1,41 +1,143 @@
 package organisation_api
 
+import "time"
+
 // dataHolder Auxiliary struct for handling the responses and requests.
 type dataHolder struct {
-	Data AccountData `json:"data,omitempty"`
+	Data  AccountData `json:"data,omitempty"`
+	Links *Links      `json:"links,omitempty"`
 }
 
 // ClientResponse Represents a response from the API client, not the API itself.
 type ClientResponse struct {
-	Data       *AccountData
+	Data *AccountData
+	// Links Links of the resource returned by the server, e.g. self.
+	Links      *Links
 	StatusCode int
 	Success    bool
+	// PreExisting Set when a create resolved a conflict with an identical account that already existed.
+	PreExisting bool
 }
 
 // AccountData Model representing an account in the server.
 type AccountData struct {
-	Attributes     *AccountAttributes `json:"attributes,omitempty"`
-	ID             string             `json:"id,omitempty"`
-	OrganisationID string             `json:"organisation_id,omitempty"`
-	Type           string             `json:"type,omitempty"`
-	Version        *int64             `json:"version,omitempty"`
+	Attributes     *AccountAttributes    `json:"attributes,omitempty"`
+	CreatedOn      *time.Time            `json:"created_on,omitempty"`
+	ID             string                `json:"id,omitempty"`
+	ModifiedOn     *time.Time            `json:"modified_on,omitempty"`
+	OrganisationID string                `json:"organisation_id,omitempty"`
+	Relationships  *AccountRelationships `json:"relationships,omitempty"`
+	Type           string                `json:"type,omitempty"`
+	Version        *int64                `json:"version,omitempty"`
 }
 
 // AccountAttributes Model representing the attributes of an account.
 type AccountAttributes struct {
-	AccountClassification   *string  `json:"account_classification,omitempty"`
-	AccountMatchingOptOut   *bool    `json:"account_matching_opt_out,omitempty"`
-	AccountNumber           string   `json:"account_number,omitempty"`
-	AlternativeNames        []string `json:"alternative_names,omitempty"`
-	BankID                  string   `json:"bank_id,omitempty"`
-	BankIDCode              string   `json:"bank_id_code,omitempty"`
-	BaseCurrency            string   `json:"base_currency,omitempty"`
-	Bic                     string   `json:"bic,omitempty"`
-	Country                 *string  `json:"country,omitempty"`
-	Iban                    string   `json:"iban,omitempty"`
-	JointAccount            *bool    `json:"joint_account,omitempty"`
-	Name                    []string `json:"name,omitempty"`
-	SecondaryIdentification string   `json:"secondary_identification,omitempty"`
-	Status                  *string  `json:"status,omitempty"`
-	Switched                *bool    `json:"switched,omitempty"`
+	AcceptanceQualifier        string                      `json:"acceptance_qualifier,omitempty"`
+	AccountClassification      *AccountClassification      `json:"account_classification,omitempty"`
+	AccountMatchingOptOut      *bool                       `json:"account_matching_opt_out,omitempty"`
+	AccountNumber              string                      `json:"account_number,omitempty"`
+	AlternativeNames           []string                    `json:"alternative_names,omitempty"`
+	BankID                     string                      `json:"bank_id,omitempty"`
+	BankIDCode                 BankIDCode                  `json:"bank_id_code,omitempty"`
+	BaseCurrency               Currency                    `json:"base_currency,omitempty"`
+	Bic                        string                      `json:"bic,omitempty"`
+	Country                    *Country                    `json:"country,omitempty"`
+	Iban                       string                      `json:"iban,omitempty"`
+	JointAccount               *bool                       `json:"joint_account,omitempty"`
+	Name                       []string                    `json:"name,omitempty"`
+	NameMatchingStatus         string                      `json:"name_matching_status,omitempty"`
+	OrganisationIdentification *OrganisationIdentification `json:"organisation_identification,omitempty"`
+	PrivateIdentification      *PrivateIdentification      `json:"private_identification,omitempty"`
+	ProcessingService          string                      `json:"processing_service,omitempty"`
+	ReferenceMask              string                      `json:"reference_mask,omitempty"`
+	SecondaryIdentification    string                      `json:"secondary_identification,omitempty"`
+	Status                     *AccountStatus              `json:"status,omitempty"`
+	StatusReason               string                      `json:"status_reason,omitempty"`
+	Switched                   *bool                       `json:"switched,omitempty"`
+	UserDefinedInformation     string                      `json:"user_defined_information,omitempty"`
+	ValidationType             string                      `json:"validation_type,omitempty"`
+}
+
+// PrivateIdentification Model representing the identification of an individual holding an account.
+type PrivateIdentification struct {
+	Address        []string `json:"address,omitempty"`
+	BirthCountry   string   `json:"birth_country,omitempty"`
+	BirthDate      string   `json:"birth_date,omitempty"`
+	City           string   `json:"city,omitempty"`
+	Country        string   `json:"country,omitempty"`
+	Identification string   `json:"identification,omitempty"`
+}
+
+// OrganisationIdentification Model representing the identification of an organisation holding an account.
+type OrganisationIdentification struct {
+	Actors         []OrganisationActor `json:"actors,omitempty"`
+	Address        []string            `json:"address,omitempty"`
+	City           string              `json:"city,omitempty"`
+	Country        string              `json:"country,omitempty"`
+	Identification string              `json:"identification,omitempty"`
+}
+
+// OrganisationActor Model representing a person acting on behalf of an organisation.
+type OrganisationActor struct {
+	BirthDate string   `json:"birth_date,omitempty"`
+	Name      []string `json:"name,omitempty"`
+	Residency string   `json:"residency,omitempty"`
+}
+
+// AccountRelationships Model representing the resources related to an account.
+type AccountRelationships struct {
+	AccountEvents *RelationshipData `json:"account_events,omitempty"`
+	MasterAccount *RelationshipData `json:"master_account,omitempty"`
+}
+
+// RelationshipData Model representing the resources of a relationship.
+type RelationshipData struct {
+	Data []ResourceIdentifier `json:"data"`
+}
+
+// ResourceIdentifier Model representing a reference to another resource.
+type ResourceIdentifier struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// accountListHolder Auxiliary struct for handling the list responses.
+type accountListHolder struct {
+	Data  []AccountData `json:"data"`
+	Links *Links        `json:"links,omitempty"`
+}
+
+// Links Model representing the pagination links returned by the server.
+type Links struct {
+	First string `json:"first,omitempty"`
+	Last  string `json:"last,omitempty"`
+	Next  string `json:"next,omitempty"`
+	Prev  string `json:"prev,omitempty"`
+	Self  string `json:"self,omitempty"`
+}
+
+// ListResponse Represents a page of accounts from the API client, not the API itself.
+type ListResponse struct {
+	Data       []AccountData
+	Links      *Links
+	StatusCode int
+	Success    bool
+}
+
+// ListOptions Pagination and filtering options for listing accounts. Zero values are not sent.
+type ListOptions struct {
+	PageNumber int
+	PageSize   int
+	Filter     AccountFilter
+}
+
+// AccountFilter Filters for listing accounts. Multiple values for the same field are OR'ed by the server.
+type AccountFilter struct {
+	BankID        []string
+	BankIDCode    []string
+	AccountNumber []string
+	Iban          []string
+	Country       []string
+	CustomerID    []string
 }
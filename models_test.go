@@ -0,0 +1,143 @@
+//go:build !integration
+// +build !integration
+
+package organisation_api
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/CG-SS/organisation-api/fakeapi"
+)
+
+// fullAccountJSON Account resource as returned by the server, with every field of the model set.
+const fullAccountJSON = `{
+	"data": {
+		"attributes": {
+			"acceptance_qualifier": "same_day",
+			"account_classification": "Business",
+			"account_matching_opt_out": false,
+			"account_number": "41426819",
+			"alternative_names": ["Sam Holder"],
+			"bank_id": "400300",
+			"bank_id_code": "GBDSC",
+			"base_currency": "GBP",
+			"bic": "NWBKGB22",
+			"country": "GB",
+			"iban": "GB11NWBK40030041426819",
+			"joint_account": false,
+			"name": ["Samantha Holder"],
+			"name_matching_status": "supported",
+			"organisation_identification": {
+				"actors": [{"birth_date": "1970-01-01", "name": ["Jeff Page"], "residency": "GB"}],
+				"address": ["10 Avenue des Champs"],
+				"city": "Paris",
+				"country": "FR",
+				"identification": "123654"
+			},
+			"private_identification": {
+				"address": ["10 Avenue des Champs"],
+				"birth_country": "GB",
+				"birth_date": "2017-07-23",
+				"city": "London",
+				"country": "GB",
+				"identification": "13YH458762"
+			},
+			"processing_service": "ABC Bank",
+			"reference_mask": "############",
+			"secondary_identification": "A1B2C3D4",
+			"status": "confirmed",
+			"status_reason": "unspecified",
+			"switched": false,
+			"user_defined_information": "Some important info",
+			"validation_type": "card"
+		},
+		"created_on": "2021-06-16T09:03:43.345Z",
+		"id": "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+		"modified_on": "2021-06-16T09:05:12.3Z",
+		"organisation_id": "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
+		"relationships": {
+			"account_events": {"data": [{"id": "c1023677-70ee-417a-9a6a-e211241f1e9c", "type": "account_events"}]},
+			"master_account": {"data": [{"id": "a52d13a4-f435-4c00-cfad-f5e7ac5972df", "type": "accounts"}]}
+		},
+		"type": "accounts",
+		"version": 3
+	},
+	"links": {"self": "/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"}
+}`
+
+func TestAccountData_JSONRoundTrip(t *testing.T) {
+	var holder dataHolder
+	if err := json.Unmarshal([]byte(fullAccountJSON), &holder); err != nil {
+		t.Fatal(err)
+	}
+
+	if holder.Data.Attributes.PrivateIdentification == nil || holder.Data.Attributes.PrivateIdentification.BirthDate != "2017-07-23" {
+		t.Fatal("Expected private identification, got", holder.Data.Attributes.PrivateIdentification)
+	}
+	if holder.Data.Relationships == nil || holder.Data.Relationships.MasterAccount.Data[0].Type != "accounts" {
+		t.Fatal("Expected master account relationship, got", holder.Data.Relationships)
+	}
+	if holder.Data.CreatedOn == nil || holder.Data.CreatedOn.Year() != 2021 {
+		t.Fatal("Expected created on timestamp, got", holder.Data.CreatedOn)
+	}
+	if holder.Links == nil || holder.Links.Self == "" {
+		t.Fatal("Expected self link, got", holder.Links)
+	}
+
+	b, err := json.Marshal(holder)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var expected, got map[string]interface{}
+	if err := json.Unmarshal([]byte(fullAccountJSON), &expected); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expected, got) {
+		t.Fatal("Expected the account to survive a round trip unchanged, got", string(b))
+	}
+}
+
+func TestOrganisationApiClient_FetchAccountKeepsAllFields(t *testing.T) {
+	s := fakeapi.NewServer()
+	defer s.Close()
+
+	c, err := NewClient(WithBaseURL(s.RootURL().String()), WithHTTPClient(s.Client()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var holder dataHolder
+	if err := json.Unmarshal([]byte(fullAccountJSON), &holder); err != nil {
+		t.Fatal(err)
+	}
+	sent := holder.Data
+	sent.Version, sent.CreatedOn, sent.ModifiedOn = nil, nil, nil
+
+	if _, err := c.CreateAccount(sent); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := c.FetchAccountWithContext(sent.ID, context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(resp.Data.Attributes, sent.Attributes) {
+		t.Fatal("Expected", sent.Attributes, "got", resp.Data.Attributes)
+	}
+	if !reflect.DeepEqual(resp.Data.Relationships, sent.Relationships) {
+		t.Fatal("Expected", sent.Relationships, "got", resp.Data.Relationships)
+	}
+	if resp.Data.CreatedOn == nil || resp.Data.ModifiedOn == nil {
+		t.Fatal("Expected server timestamps, got", resp.Data.CreatedOn, resp.Data.ModifiedOn)
+	}
+	if resp.Links == nil || resp.Links.Self == "" {
+		t.Fatal("Expected self link, got", resp.Links)
+	}
+}
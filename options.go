@@ -0,0 +1,236 @@
+package organisation_api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Option Configures the client built by NewClient.
+type Option func(o *clientOptions) error
+
+// clientOptions Settings collected from the options before building the client.
+type clientOptions struct {
+	config      *ClientConfig
+	httpClient  *http.Client
+	timeout     time.Duration
+	middlewares []Middleware
+	// clientCredentials Set by WithClientCredentials, whose bearer tokens would replace the signature of a Signer.
+	clientCredentials bool
+}
+
+// WithBaseURL Sets the organisation root URL, e.g. http://localhost:8080/v1/organisation/, overriding API_URL.
+func WithBaseURL(rawUrl string) Option {
+	return func(o *clientOptions) error {
+		u, err := parseRootUrl(rawUrl)
+		if err != nil {
+			return err
+		}
+
+		o.config.RootUrl = u
+		return nil
+	}
+}
+
+// WithHTTPClient Uses a copy of the given http.Client to send the requests. Its timeout is kept unless WithTimeout is
+// also given.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *clientOptions) error {
+		if httpClient == nil {
+			return errors.New("organisation api: nil http client")
+		}
+
+		o.httpClient = httpClient
+		return nil
+	}
+}
+
+// WithValidateBeforeCreate Makes CreateAccount run AccountData.Validate before sending the request, failing with
+// ValidationErrors.
+func WithValidateBeforeCreate(validate bool) Option {
+	return func(o *clientOptions) error {
+		o.config.ValidateBeforeCreate = validate
+		return nil
+	}
+}
+
+// WithResolveCreateConflicts Makes CreateAccount treat a 409 as a success when an identical account already exists,
+// returning it with ClientResponse.PreExisting set.
+func WithResolveCreateConflicts(resolve bool) Option {
+	return func(o *clientOptions) error {
+		o.config.ResolveCreateConflicts = resolve
+		return nil
+	}
+}
+
+// WithTimeout Sets the timeout of each attempt of a request. Retried requests can take up to MaxAttempts times as
+// long, plus the delays between attempts; a context deadline bounds the whole call.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) error {
+		if timeout <= 0 {
+			return fmt.Errorf("organisation api: invalid timeout %v", timeout)
+		}
+
+		o.timeout = timeout
+		return nil
+	}
+}
+
+// WithLogger Sends the events of the client to the given leveled logger, see NewStdLogger to use a *log.Logger.
+func WithLogger(logger Logger) Option {
+	return func(o *clientOptions) error {
+		o.config.Logger = logger
+		return nil
+	}
+}
+
+// WithRedactor Sets how personal data is masked out of the logs, replacing DefaultRedactor. A Redactor without fields
+// disables redaction.
+func WithRedactor(redactor *Redactor) Option {
+	return func(o *clientOptions) error {
+		if redactor == nil {
+			return errors.New("organisation api: nil redactor")
+		}
+
+		o.config.Redactor = redactor
+		return nil
+	}
+}
+
+// WithRetryPolicy Sets the retry policy, nil disables retries.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(o *clientOptions) error {
+		o.config.RetryPolicy = policy
+		return nil
+	}
+}
+
+// WithRequestID Sets the X-Request-Id header of every request without one, using generate or random hex IDs when nil.
+// Unlike RequestIDMiddleware, the ID is shared by the retries of the request and shows in its log events and spans.
+func WithRequestID(generate func() string) Option {
+	return func(o *clientOptions) error {
+		if generate == nil {
+			generate = randomRequestID
+		}
+
+		o.config.RequestID = generate
+		return nil
+	}
+}
+
+// WithUserAgent Sets the User-Agent header of every request.
+func WithUserAgent(userAgent string) Option {
+	return func(o *clientOptions) error {
+		o.config.UserAgent = userAgent
+		return nil
+	}
+}
+
+// WithDefaultHeaders Adds the given headers to every request.
+func WithDefaultHeaders(headers http.Header) Option {
+	return func(o *clientOptions) error {
+		if o.config.DefaultHeaders == nil {
+			o.config.DefaultHeaders = http.Header{}
+		}
+
+		for k, values := range headers {
+			for _, v := range values {
+				o.config.DefaultHeaders.Add(k, v)
+			}
+		}
+		return nil
+	}
+}
+
+// WithHeader Adds a single header to every request.
+func WithHeader(key string, value string) Option {
+	return WithDefaultHeaders(http.Header{key: []string{value}})
+}
+
+// WithSigner Signs every request with the given signer, see NewHTTPSigner. The signature goes in the Authorization
+// header, so it can't be combined with WithClientCredentials.
+func WithSigner(signer RequestSigner) Option {
+	return func(o *clientOptions) error {
+		o.config.Signer = signer
+		return nil
+	}
+}
+
+// WithTracer Traces every operation with the given tracer, see SpanRecorder.
+func WithTracer(tracer Tracer) Option {
+	return func(o *clientOptions) error {
+		o.config.Tracer = tracer
+		return nil
+	}
+}
+
+// WithMetrics Records the metrics of every operation in the given collector, which can be shared between clients.
+func WithMetrics(metrics *Metrics) Option {
+	return func(o *clientOptions) error {
+		o.config.Metrics = metrics
+		return nil
+	}
+}
+
+// WithRateLimit Limits the client to ratePerSecond requests on average with bursts of up to burst requests, adapting
+// to the rate limiting headers of the server. Use WithRateLimiter to share a limiter between clients.
+func WithRateLimit(ratePerSecond float64, burst int) Option {
+	return func(o *clientOptions) error {
+		limiter, err := NewRateLimiter(ratePerSecond, burst)
+		if err != nil {
+			return err
+		}
+
+		o.config.RateLimiter = limiter
+		return nil
+	}
+}
+
+// WithRateLimiter Limits the requests of the client with the given limiter.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(o *clientOptions) error {
+		o.config.RateLimiter = limiter
+		return nil
+	}
+}
+
+// WithCircuitBreaker Guards the requests of the client with the given circuit breaker.
+func WithCircuitBreaker(breaker *CircuitBreaker) Option {
+	return func(o *clientOptions) error {
+		o.config.CircuitBreaker = breaker
+		return nil
+	}
+}
+
+// WithClientCredentials Authenticates every request with bearer tokens obtained through the OAuth2 client credentials
+// grant, see OAuth2Transport. The tokens go in the Authorization header, so it can't be combined with WithSigner.
+func WithClientCredentials(config OAuth2Config) Option {
+	return func(o *clientOptions) error {
+		if err := config.validate(); err != nil {
+			return err
+		}
+
+		o.clientCredentials = true
+		o.middlewares = append(o.middlewares, func(next http.RoundTripper) http.RoundTripper {
+			t, _ := NewOAuth2Transport(config, next)
+			return t
+		})
+		return nil
+	}
+}
+
+// WithMiddleware Adds middlewares around the transport of the client. Middlewares given first, by this or previous
+// options, are the outermost.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(o *clientOptions) error {
+		for _, m := range middlewares {
+			if m == nil {
+				return errors.New("organisation api: nil middleware")
+			}
+		}
+
+		o.middlewares = append(o.middlewares, middlewares...)
+		return nil
+	}
+}
@@ -0,0 +1,140 @@
+package organisation_api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultThrottleDelay Pause applied after a 429 without any header telling how long to wait.
+const defaultThrottleDelay = time.Second
+
+// RateLimiter Token bucket limiting the requests sent by the clients sharing it, every attempt taking a token. It
+// adapts to the server: a 429 or an exhausted X-RateLimit-Remaining pauses it until the time given by Retry-After or
+// X-RateLimit-Reset, and the tokens are capped by the remaining requests the server reports. Safe for concurrent use.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	// pausedUntil Set by the server responses, no token is handed out before it.
+	pausedUntil time.Time
+	now         func() time.Time
+}
+
+// NewRateLimiter Builds a limiter allowing ratePerSecond requests on average, with bursts of up to burst requests.
+func NewRateLimiter(ratePerSecond float64, burst int) (*RateLimiter, error) {
+	if ratePerSecond <= 0 || burst < 1 {
+		return nil, errors.New("organisation api: rate limiter needs a positive rate and burst")
+	}
+
+	return &RateLimiter{
+		rate:   ratePerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+	}, nil
+}
+
+// Wait Blocks until a token is available or the context is done. Fails right away, without taking a token, when the
+// wait would outlast the context deadline.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := l.now()
+	l.refill(now)
+
+	// reserve the token now, so concurrent callers queue behind each other
+	l.tokens--
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	if paused := l.pausedUntil.Sub(now); paused > wait {
+		wait = paused
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && now.Add(wait).After(deadline) {
+		l.tokens++
+		l.mu.Unlock()
+		return fmt.Errorf("organisation api: rate limit wait of %s exceeds the context deadline: %w", wait, context.DeadlineExceeded)
+	}
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	if err := sleepWithContext(ctx, wait); err != nil {
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+// Observe Adapts the limiter to the rate limiting headers of the response.
+func (l *RateLimiter) Observe(resp *http.Response) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.refill(now)
+
+	reset, hasReset := parseRateLimitReset(resp.Header.Get("X-RateLimit-Reset"), now)
+	if remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining")); err == nil && remaining >= 0 {
+		l.tokens = math.Min(l.tokens, float64(remaining))
+		if remaining == 0 && hasReset {
+			l.pauseUntil(reset)
+		}
+	}
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		return
+	}
+
+	l.tokens = math.Min(l.tokens, 0)
+	switch d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); {
+	case ok:
+		l.pauseUntil(now.Add(d))
+	case hasReset:
+		l.pauseUntil(reset)
+	default:
+		l.pauseUntil(now.Add(defaultThrottleDelay))
+	}
+}
+
+func (l *RateLimiter) refill(now time.Time) {
+	if !l.last.IsZero() {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+}
+
+func (l *RateLimiter) pauseUntil(t time.Time) {
+	if t.After(l.pausedUntil) {
+		l.pausedUntil = t
+	}
+}
+
+// parseRateLimitReset Parses X-RateLimit-Reset, either as seconds until the reset or as a Unix timestamp.
+func parseRateLimitReset(value string, now time.Time) (time.Time, bool) {
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n < 0 {
+		return time.Time{}, false
+	}
+
+	// a delta would never be that large, so it's an epoch
+	if n > 1e9 {
+		return time.Unix(n, 0), true
+	}
+
+	return now.Add(time.Duration(n) * time.Second), true
+}
@@ -0,0 +1,123 @@
+//go:build !integration
+// +build !integration
+
+package organisation_api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Wait(t *testing.T) {
+	limiter, err := NewRateLimiter(100, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := limiter.Wait(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// the burst of 2 goes through right away, the other 4 are spaced by 10ms
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Fatal("Expected the limiter to block for about 40ms, took", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("Expected the wait to outlast the deadline, got", err)
+	}
+
+	if _, err := NewRateLimiter(0, 1); err == nil {
+		t.Fatal("Expected invalid rate error")
+	}
+}
+
+func TestRateLimiter_Observe(t *testing.T) {
+	testCases := []struct {
+		name    string
+		status  int
+		headers http.Header
+		paused  bool
+	}{
+		{"Pauses on 429 with Retry-After", http.StatusTooManyRequests, http.Header{"Retry-After": []string{"1"}}, true},
+		{"Pauses on 429 without headers", http.StatusTooManyRequests, http.Header{}, true},
+		{"Pauses on exhausted remaining", http.StatusOK, http.Header{"X-Ratelimit-Remaining": []string{"0"}, "X-Ratelimit-Reset": []string{"1"}}, true},
+		{"Pauses until epoch reset", http.StatusOK, http.Header{"X-Ratelimit-Remaining": []string{"0"},
+			"X-Ratelimit-Reset": []string{strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10)}}, true},
+		{"Keeps going with remaining requests", http.StatusOK, http.Header{"X-Ratelimit-Remaining": []string{"10"}, "X-Ratelimit-Reset": []string{"1"}}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			limiter, err := NewRateLimiter(1000, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			limiter.Observe(&http.Response{StatusCode: tc.status, Header: tc.headers})
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			err = limiter.Wait(ctx)
+			if paused := errors.Is(err, context.DeadlineExceeded); paused != tc.paused {
+				t.Fatal("Paused condition didn't match! Got", err, "should've been", tc.paused)
+			}
+		})
+	}
+}
+
+func TestNewClient_WithRateLimit(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient(WithBaseURL(ts.URL+"/v1/organisation/"), WithRateLimit(100, 1), WithRetryPolicy(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.DeleteAccount(mockAccountData.ID, 0); !errors.Is(err, ErrRateLimited) {
+		t.Fatal("Expected rate limited error, got", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := c.DeleteAccountWithContext(mockAccountData.ID, 0, ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("Expected the client to hold requests after a 429, got", err)
+	}
+	if requests != 1 {
+		t.Fatal("Request shouldn't have been sent while throttled, got", requests, "requests")
+	}
+
+	if _, err := c.DeleteAccount(mockAccountData.ID, 0); err != nil {
+		t.Fatal("Expected request to go through after the pause, got", err)
+	}
+
+	if _, err := NewClient(WithRateLimit(-1, 1)); err == nil {
+		t.Fatal("Expected invalid rate limit error")
+	}
+}
@@ -0,0 +1,211 @@
+package organisation_api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+const redactionMask = "****"
+
+// DefaultRedactedFields JSON attributes holding personal data, masked in the logs by default.
+var DefaultRedactedFields = []string{"iban", "account_number", "name", "alternative_names", "secondary_identification",
+	"private_identification", "birth_date"}
+
+// DefaultRedactor Masks the DefaultRedactedFields completely. Used when the client config has no Redactor.
+var DefaultRedactor = &Redactor{
+	Fields: DefaultRedactedFields,
+	Mask:   MaskAll,
+}
+
+// Redactor Masks personal data out of the payloads and URLs written to the logs. A Redactor without fields leaves
+// everything untouched.
+type Redactor struct {
+	// Fields JSON attribute names to mask wherever they appear in a payload, and in filter query parameters.
+	Fields []string
+	// Mask Masks a single value. Defaults to MaskAll.
+	Mask func(value string) string
+}
+
+// MaskAll Replaces the whole value.
+func MaskAll(string) string {
+	return redactionMask
+}
+
+// KeepLast Masks all but the last n characters of the value, values of up to n characters being masked entirely.
+func KeepLast(n int) func(value string) string {
+	return func(value string) string {
+		if utf8.RuneCountInString(value) <= n {
+			return redactionMask
+		}
+
+		runes := []rune(value)
+		return redactionMask + string(runes[len(runes)-n:])
+	}
+}
+
+// RedactJSON Returns a copy of the JSON payload with the values of the redacted fields masked. Payloads which aren't
+// JSON can't be inspected, so they are masked entirely.
+func (r *Redactor) RedactJSON(b []byte) []byte {
+	if len(r.Fields) == 0 || len(b) == 0 {
+		return b
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return []byte(fmt.Sprintf("<%d bytes redacted>", len(b)))
+	}
+
+	redacted, err := json.Marshal(r.redactValue(v, false))
+	if err != nil {
+		return []byte(fmt.Sprintf("<%d bytes redacted>", len(b)))
+	}
+
+	return redacted
+}
+
+// RedactURL Returns the URL with the values of the filters on redacted fields masked, e.g. filter[iban].
+func (r *Redactor) RedactURL(u *url.URL) string {
+	if len(r.Fields) == 0 || u.RawQuery == "" {
+		return u.String()
+	}
+
+	q := u.Query()
+	for _, f := range r.Fields {
+		key := "filter[" + f + "]"
+		if values, ok := q[key]; ok {
+			for i, v := range values {
+				values[i] = r.mask(v)
+			}
+		}
+	}
+
+	redacted := *u
+	redacted.RawQuery = q.Encode()
+
+	return redacted.String()
+}
+
+func (r *Redactor) redactValue(v interface{}, sensitive bool) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, child := range value {
+			value[k] = r.redactValue(child, sensitive || r.isRedacted(k))
+		}
+	case []interface{}:
+		for i, child := range value {
+			value[i] = r.redactValue(child, sensitive)
+		}
+	case string:
+		if sensitive {
+			return r.mask(value)
+		}
+	default:
+		if sensitive && value != nil {
+			return r.mask(fmt.Sprint(value))
+		}
+	}
+
+	return v
+}
+
+func (r *Redactor) isRedacted(field string) bool {
+	for _, f := range r.Fields {
+		if strings.EqualFold(f, field) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redactError Returns the error with the URL of the *url.Error in its chain redacted, as transport errors quote the
+// full request URL. The original error stays reachable through Unwrap.
+func (r *Redactor) redactError(err error) error {
+	var urlErr *url.Error
+	if err == nil || !errors.As(err, &urlErr) {
+		return err
+	}
+
+	redacted := "<url redacted>"
+	if u, parseErr := url.Parse(urlErr.URL); parseErr == nil {
+		redacted = r.RedactURL(u)
+	}
+	if redacted == urlErr.URL {
+		return err
+	}
+
+	return &redactedError{msg: strings.ReplaceAll(err.Error(), urlErr.URL, redacted), err: err}
+}
+
+// redactedError Error whose message had personal data masked.
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+func (r *Redactor) mask(value string) string {
+	if r.Mask == nil {
+		return MaskAll(value)
+	}
+
+	return r.Mask(value)
+}
+
+// redactor Returns the redactor of the client config, or DefaultRedactor.
+func (c *OrganisationApiClient) redactor() *Redactor {
+	if c.ClientConfig.Redactor != nil {
+		return c.ClientConfig.Redactor
+	}
+
+	return DefaultRedactor
+}
+
+// redactedBody Payload given to the loggers, only read and redacted when formatted so clients without logging don't
+// pay for it.
+type redactedBody struct {
+	redactor *Redactor
+	body     []byte
+	getBody  func() (io.ReadCloser, error)
+}
+
+func (b redactedBody) String() string {
+	body := b.body
+	if b.getBody != nil {
+		r, err := b.getBody()
+		if err != nil {
+			return ""
+		}
+		defer r.Close()
+		body, _ = io.ReadAll(r)
+	}
+
+	return string(b.redactor.RedactJSON(body))
+}
+
+// MarshalJSON Logs the redacted payload as a JSON string with JSON handlers.
+func (b redactedBody) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.String())
+}
+
+func (c *OrganisationApiClient) redactBody(b []byte) redactedBody {
+	return redactedBody{redactor: c.redactor(), body: b}
+}
+
+// redactRequestBody Payload of the request, read again through its GetBody when formatted.
+func (c *OrganisationApiClient) redactRequestBody(req *http.Request) redactedBody {
+	return redactedBody{redactor: c.redactor(), getBody: req.GetBody}
+}
@@ -0,0 +1,196 @@
+//go:build !integration
+// +build !integration
+
+package organisation_api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+var sensitiveValues = []string{"10000004", "GB71NWBK40030212764204", "Kelvin", "Klein"}
+
+func TestRedactor_RedactJSON(t *testing.T) {
+	payload, err := json.Marshal(dataHolder{Data: mockAccountData})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	redacted := string(DefaultRedactor.RedactJSON(payload))
+	for _, v := range sensitiveValues {
+		if strings.Contains(redacted, v) {
+			t.Fatal("Sensitive value", v, "wasn't redacted from", redacted)
+		}
+	}
+	if !strings.Contains(redacted, `"bank_id":"400302"`) || !strings.Contains(redacted, `"name":["****","****"]`) {
+		t.Fatal("Only the redacted fields should be masked, got", redacted)
+	}
+
+	if string(DefaultRedactor.RedactJSON([]byte("Kelvin Klein"))) != "<12 bytes redacted>" {
+		t.Fatal("Non-JSON payloads should be masked entirely")
+	}
+	if !bytes.Equal((&Redactor{}).RedactJSON(payload), payload) {
+		t.Fatal("Redactor without fields should leave the payload untouched")
+	}
+}
+
+func TestKeepLast(t *testing.T) {
+	r := &Redactor{Fields: []string{"iban", "account_number"}, Mask: KeepLast(4)}
+
+	redacted := string(r.RedactJSON([]byte(`{"iban":"GB71NWBK40030212764204","account_number":"123","bic":"NWBKGB42"}`)))
+	expected := `{"account_number":"****","bic":"NWBKGB42","iban":"****4204"}`
+	if redacted != expected {
+		t.Fatal("Expected", expected, "got", redacted)
+	}
+}
+
+func TestRedactor_RedactURL(t *testing.T) {
+	u, err := url.Parse("http://localhost/v1/organisation/accounts?filter[iban]=GB71NWBK40030212764204&filter[country]=GB")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	redacted := DefaultRedactor.RedactURL(u)
+	if strings.Contains(redacted, "GB71NWBK40030212764204") || !strings.Contains(redacted, "filter%5Bcountry%5D=GB") {
+		t.Fatal("Expected the iban filter to be masked, got", redacted)
+	}
+	if u.Query().Get("filter[iban]") != "GB71NWBK40030212764204" {
+		t.Fatal("Original URL was modified!")
+	}
+}
+
+func TestClient_redactsLogs(t *testing.T) {
+	buf := &bytes.Buffer{}
+	c, err := NewClient(
+		WithLogger(NewStdLogger(log.New(buf, "", 0), LevelDebug)),
+		WithRetryPolicy(nil),
+		WithHTTPClient(&http.Client{
+			Transport: roundTripAux(func(r *http.Request) (*http.Response, error) {
+				b, err := ioutil.ReadAll(r.Body)
+				if err != nil {
+					return nil, err
+				}
+
+				// echoes the account on create, rejects it as a duplicate on the second attempt
+				if strings.Contains(buf.String(), "received body") {
+					return &http.Response{
+						StatusCode: http.StatusConflict,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"error_message":"duplicate","iban":"GB71NWBK40030212764204"}`)),
+					}, nil
+				}
+				return &http.Response{
+					StatusCode: http.StatusCreated,
+					Body:       ioutil.NopCloser(bytes.NewReader(b)),
+				}, nil
+			}),
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.CreateAccount(mockAccountData); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.CreateAccount(mockAccountData); err == nil {
+		t.Fatal("Expected conflict")
+	}
+
+	logs := buf.String()
+	for _, event := range []string{"request start", "received body", "received API error"} {
+		if !strings.Contains(logs, event) {
+			t.Fatal("Missing", event, "event in", logs)
+		}
+	}
+	for _, v := range sensitiveValues {
+		if strings.Contains(logs, v) {
+			t.Fatal("Sensitive value", v, "was logged:", logs)
+		}
+	}
+}
+
+func TestClient_redactsTransportErrors(t *testing.T) {
+	buf := &bytes.Buffer{}
+	recorder := NewSpanRecorder()
+	c, err := NewClient(
+		WithLogger(NewStdLogger(log.New(buf, "", 0), LevelDebug)),
+		WithTracer(recorder),
+		WithRetryPolicy(nil),
+		WithHTTPClient(&http.Client{
+			Transport: roundTripAux(func(r *http.Request) (*http.Response, error) {
+				return nil, errors.New("connection refused")
+			}),
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.ListAccounts(ListOptions{Filter: AccountFilter{Iban: []string{"GB71NWBK40030212764204"}}})
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		t.Fatal("Expected the caller to get the original error, got", err)
+	}
+
+	var errorLine string
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if strings.Contains(line, "request error") {
+			errorLine = line
+		}
+	}
+	if !strings.Contains(errorLine, "error=") || !strings.Contains(errorLine, "connection refused") {
+		t.Fatal("Expected the error field to be logged, got", errorLine)
+	}
+	if strings.Contains(buf.String(), "GB71NWBK40030212764204") {
+		t.Fatal("The iban was logged through the error:", buf.String())
+	}
+
+	span := findSpan(recorder.Spans(), "organisation_api.ListAccounts")
+	if span == nil || span.Err == nil {
+		t.Fatal("Expected the operation span to record the error, got", span)
+	}
+	if strings.Contains(span.Err.Error(), "GB71NWBK40030212764204") {
+		t.Fatal("The iban was recorded in the span error:", span.Err)
+	}
+}
+
+func TestClient_redactsRetriedTransportErrors(t *testing.T) {
+	var events []logEvent
+	c, err := NewClient(
+		WithLogger(recordingLogger(&events)),
+		WithRetryPolicy(&RetryPolicy{MaxAttempts: 3}),
+		WithHTTPClient(&http.Client{
+			Transport: roundTripAux(func(r *http.Request) (*http.Response, error) {
+				return nil, errors.New("connection refused")
+			}),
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.ListAccounts(ListOptions{Filter: AccountFilter{Iban: []string{"GB71NWBK40030212764204"}}}); err == nil {
+		t.Fatal("Expected transport error")
+	}
+
+	retries := 0
+	for _, event := range events {
+		if event.msg == "request retry" {
+			retries++
+		}
+		if logged := fmt.Sprint(event.fields); strings.Contains(logged, "40030212764204") {
+			t.Fatal("The iban was logged in the", event.msg, "event:", logged)
+		}
+	}
+	if retries != 2 {
+		t.Fatal("Expected 2 retry events, got", retries)
+	}
+}
@@ -0,0 +1,259 @@
+package organisation_api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const idempotencyKeyHeader = "Idempotency-Key"
+
+type idempotencyKeyCtxKey struct{}
+
+// RetryPolicy Policy controlling how failed requests are retried.
+// GET, HEAD, OPTIONS, PUT and DELETE requests are retried freely. POST and PATCH requests are only retried when the
+// server rejected them with 429, when an idempotency key is set on the context or when RetryNonIdempotent is enabled.
+type RetryPolicy struct {
+	// MaxAttempts Total number of attempts, including the first one. Values lower than 2 disable retries.
+	MaxAttempts int
+	// BaseDelay Delay before the first retry, doubled on every following attempt.
+	BaseDelay time.Duration
+	// MaxDelay Upper bound for the delay between attempts, including the ones asked by Retry-After.
+	MaxDelay time.Duration
+	// Jitter Fraction, between 0 and 1, of each delay that is randomized.
+	Jitter float64
+	// RetryableStatuses Status codes considered transient.
+	RetryableStatuses map[int]bool
+	// RespectRetryAfter Uses the Retry-After header of the response as the delay when present.
+	RespectRetryAfter bool
+	// RetryNonIdempotent Retries POST and PATCH requests as if they were idempotent.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy Retries transient failures up to 3 attempts with exponential backoff.
+var DefaultRetryPolicy = &RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   100 * time.Millisecond,
+	MaxDelay:    2 * time.Second,
+	Jitter:      0.2,
+	RetryableStatuses: map[int]bool{
+		http.StatusTooManyRequests:     true,
+		http.StatusInternalServerError: true,
+		http.StatusBadGateway:          true,
+		http.StatusServiceUnavailable:  true,
+		http.StatusGatewayTimeout:      true,
+	},
+	RespectRetryAfter: true,
+}
+
+// WithIdempotencyKey Returns a context whose requests carry the given Idempotency-Key header, making them safe to retry.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtxKey{}, key)
+}
+
+func idempotencyKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyCtxKey{}).(string)
+	return key
+}
+
+// do Sends the request of the operation applying the retry policy of the client config, logging its start and
+// outcome and recording its metrics.
+func (c *OrganisationApiClient) do(req *http.Request, op operation) (*http.Response, error) {
+	if c.ClientConfig.RequestID != nil && req.Header.Get(requestIDHeader) == "" {
+		req.Header.Set(requestIDHeader, c.ClientConfig.RequestID())
+	}
+
+	kv := c.fields(op, req)
+	if req.GetBody != nil {
+		kv = append(kv, "body", c.redactRequestBody(req))
+	}
+	c.logger().Debug("request start", kv...)
+
+	c.ClientConfig.Metrics.startRequest(op.name, req)
+	start := time.Now()
+	resp, err := c.retry(req, op)
+	duration := time.Since(start)
+	c.ClientConfig.Metrics.endRequest(op.name, resp, err, duration)
+	c.logResult(op, req, resp, err, duration)
+	c.annotateSpan(req, resp)
+
+	return resp, err
+}
+
+func (c *OrganisationApiClient) retry(req *http.Request, op operation) (*http.Response, error) {
+	policy := c.ClientConfig.RetryPolicy
+	maxAttempts := 1
+	if policy != nil && policy.MaxAttempts > 1 {
+		maxAttempts = policy.MaxAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := c.send(req)
+		if attempt >= maxAttempts || !policy.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		delay := policy.delay(attempt, resp)
+		c.ClientConfig.Metrics.retried(op.name)
+		if resp != nil {
+			c.logger().Warn("request retry", c.fields(op, req, "attempt", attempt, "status", resp.StatusCode, "delay", delay)...)
+			drainBody(resp.Body)
+		} else {
+			c.logger().Warn("request retry", c.fields(op, req, "attempt", attempt, "error", c.redactor().redactError(err), "delay", delay)...)
+		}
+
+		if err := sleepWithContext(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// send Sends a single attempt of the request, going through the circuit breaker, waiting for the rate limiter, signing
+// it and tracing it when the client config has them. Signing comes last so the Date header isn't staled by the wait.
+func (c *OrganisationApiClient) send(req *http.Request) (*http.Response, error) {
+	breaker := c.ClientConfig.CircuitBreaker
+	var generation uint64
+	if breaker != nil {
+		var err error
+		if generation, err = breaker.allow(); err != nil {
+			return nil, err
+		}
+	}
+
+	limiter := c.ClientConfig.RateLimiter
+	if limiter != nil {
+		if err := limiter.Wait(req.Context()); err != nil {
+			if breaker != nil {
+				breaker.record(generation, nil, err, true)
+			}
+			return nil, err
+		}
+	}
+
+	if c.ClientConfig.Signer != nil {
+		if err := c.ClientConfig.Signer.Sign(req); err != nil {
+			if breaker != nil {
+				breaker.record(generation, nil, err, true)
+			}
+			return nil, err
+		}
+	}
+
+	req, endPhases := c.traceRequest(req)
+	resp, err := c.Do(req)
+	endPhases(err)
+
+	if breaker != nil {
+		// a caller giving up says nothing about the health of the API, unlike a deadline expiring while it hangs
+		breaker.record(generation, resp, err, errors.Is(req.Context().Err(), context.Canceled))
+	}
+
+	if limiter != nil && resp != nil {
+		limiter.Observe(resp)
+	}
+
+	return resp, err
+}
+
+func (p *RetryPolicy) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		if req.Context().Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) ||
+			errors.Is(err, ErrCircuitOpen) {
+			return false
+		}
+
+		return p.isIdempotent(req)
+	}
+
+	if !p.RetryableStatuses[resp.StatusCode] {
+		return false
+	}
+
+	// a throttled request was never processed, so it is safe to send again whatever the method
+	return resp.StatusCode == http.StatusTooManyRequests || p.isIdempotent(req)
+}
+
+func (p *RetryPolicy) isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return p.RetryNonIdempotent || req.Header.Get(idempotencyKeyHeader) != ""
+}
+
+func (p *RetryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	if p.RespectRetryAfter && resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return p.capDelay(d)
+		}
+	}
+
+	d := time.Duration(float64(p.BaseDelay) * math.Pow(2, float64(attempt-1)))
+	d = p.capDelay(d)
+
+	if p.Jitter > 0 {
+		d -= time.Duration(p.Jitter * rand.Float64() * float64(d))
+	}
+
+	return d
+}
+
+func (p *RetryPolicy) capDelay(d time.Duration) time.Duration {
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		return p.MaxDelay
+	}
+
+	return d
+}
+
+// parseRetryAfter Parses a Retry-After header value, either in seconds or as an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+
+	return 0, false
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func drainBody(body io.ReadCloser) {
+	_, _ = io.Copy(io.Discard, body)
+	closeBody(body)
+}
@@ -0,0 +1,174 @@
+//go:build !integration
+// +build !integration
+
+package organisation_api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testRetryPolicy = &RetryPolicy{
+	MaxAttempts:       3,
+	BaseDelay:         time.Millisecond,
+	MaxDelay:          5 * time.Millisecond,
+	Jitter:            0.5,
+	RetryableStatuses: DefaultRetryPolicy.RetryableStatuses,
+	RespectRetryAfter: true,
+}
+
+// flakyTransport Fails the first failures requests with the given status, then answers with the mock account.
+func flakyTransport(t *testing.T, failures int, failStatus int, attempts *int) roundTripAux {
+	return func(r *http.Request) (*http.Response, error) {
+		*attempts++
+
+		if r.Body != nil {
+			sent := dataHolder{}
+			if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+				t.Fatal("Body wasn't replayed on attempt", *attempts, err)
+			}
+		}
+
+		if *attempts <= failures {
+			return &http.Response{
+				StatusCode: failStatus,
+				Header:     http.Header{"Retry-After": []string{"0"}},
+				Body:       ioutil.NopCloser(strings.NewReader(`{"error_message":"try again"}`)),
+			}, nil
+		}
+
+		j, err := json.Marshal(dataHolder{Data: mockAccountData})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		status := http.StatusOK
+		if r.Method == http.MethodPost {
+			status = http.StatusCreated
+		}
+
+		return &http.Response{
+			StatusCode: status,
+			Body:       ioutil.NopCloser(strings.NewReader(string(j))),
+		}, nil
+	}
+}
+
+func newRetryTestClient(transport http.RoundTripper) *OrganisationApiClient {
+	return &OrganisationApiClient{
+		Client: &http.Client{Transport: transport},
+		ClientConfig: &ClientConfig{
+			RootUrl:        defaultRootUrl,
+			DebugLog:       nil,
+			IsDebugEnabled: false,
+			RetryPolicy:    testRetryPolicy,
+		},
+	}
+}
+
+func TestOrganisationApiClient_doRetries(t *testing.T) {
+	testCases := []struct {
+		name             string
+		failures         int
+		failStatus       int
+		create           bool
+		ctx              context.Context
+		shouldFail       bool
+		expectedAttempts int
+	}{
+		{"Retries fetch on server errors", 2, http.StatusServiceUnavailable, false, context.Background(), false, 3},
+		{"Gives up fetch after max attempts", 3, http.StatusBadGateway, false, context.Background(), true, 3},
+		{"Doesn't retry fetch on client errors", 1, http.StatusNotFound, false, context.Background(), true, 1},
+		{"Doesn't retry create on server errors", 1, http.StatusServiceUnavailable, true, context.Background(), true, 1},
+		{"Retries create when throttled", 1, http.StatusTooManyRequests, true, context.Background(), false, 2},
+		{"Retries create with idempotency key", 2, http.StatusInternalServerError, true, WithIdempotencyKey(context.Background(), "key"), false, 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			attempts := 0
+			c := newRetryTestClient(flakyTransport(t, tc.failures, tc.failStatus, &attempts))
+
+			var err error
+			if tc.create {
+				_, err = c.CreateAccountWithContext(mockAccountData, tc.ctx)
+			} else {
+				_, err = c.FetchAccountWithContext(mockAccountData.ID, tc.ctx)
+			}
+
+			if (err != nil) != tc.shouldFail {
+				t.Fatal("Fail condition didn't match! Got", err, "should've been", tc.shouldFail)
+			}
+			if attempts != tc.expectedAttempts {
+				t.Fatal("Expected", tc.expectedAttempts, "attempts, got", attempts)
+			}
+		})
+	}
+}
+
+func TestOrganisationApiClient_doRetriesWithCancelledContext(t *testing.T) {
+	attempts := 0
+	c := newRetryTestClient(roundTripAux(func(r *http.Request) (*http.Response, error) {
+		attempts++
+		return &http.Response{
+			StatusCode: http.StatusServiceUnavailable,
+			Header:     http.Header{"Retry-After": []string{"60"}},
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		}, nil
+	}))
+	c.ClientConfig.RetryPolicy = &RetryPolicy{
+		MaxAttempts:       3,
+		RetryableStatuses: DefaultRetryPolicy.RetryableStatuses,
+		RespectRetryAfter: true,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := c.FetchAccountWithContext(mockAccountData.ID, ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("Expected deadline error, got", err)
+	}
+	if attempts != 1 {
+		t.Fatal("Expected a single attempt, got", attempts)
+	}
+}
+
+func TestRetryPolicy_delay(t *testing.T) {
+	p := &RetryPolicy{
+		BaseDelay:         10 * time.Millisecond,
+		MaxDelay:          50 * time.Millisecond,
+		RespectRetryAfter: true,
+	}
+
+	testCases := []struct {
+		name       string
+		attempt    int
+		retryAfter string
+		expected   time.Duration
+	}{
+		{"Uses base delay on first retry", 1, "", 10 * time.Millisecond},
+		{"Doubles delay on each attempt", 3, "", 40 * time.Millisecond},
+		{"Caps delay", 5, "", 50 * time.Millisecond},
+		{"Uses Retry-After seconds", 1, "0", 0},
+		{"Caps Retry-After", 1, "120", 50 * time.Millisecond},
+		{"Ignores malformed Retry-After", 2, "soon", 20 * time.Millisecond},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			resp.Header.Set("Retry-After", tc.retryAfter)
+
+			if d := p.delay(tc.attempt, resp); d != tc.expected {
+				t.Fatal("Expected", tc.expected, "got", d)
+			}
+		})
+	}
+}
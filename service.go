@@ -0,0 +1,75 @@
+package organisation_api
+
+import "context"
+
+// accountsService AccountsService of a client.
+type accountsService struct {
+	c *OrganisationApiClient
+}
+
+// Accounts Returns the context-first API of the accounts resource.
+func (c *OrganisationApiClient) Accounts() AccountsService {
+	return &accountsService{c: c}
+}
+
+// Create Creates the account. When ResolveCreateConflicts is set, an identical pre-existing account is returned as is.
+func (s *accountsService) Create(ctx context.Context, data AccountData) (*AccountData, error) {
+	resp, err := s.c.createAccount(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+// Get Fetches the account with the given id.
+func (s *accountsService) Get(ctx context.Context, id string) (*AccountData, error) {
+	resp, err := s.c.fetchAccount(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+// Delete Deletes the given version of the account.
+func (s *accountsService) Delete(ctx context.Context, id string, version int64) error {
+	_, err := s.c.deleteAccount(ctx, id, version)
+	return err
+}
+
+// Update Patches the account, which must carry its current version.
+func (s *accountsService) Update(ctx context.Context, data AccountData) (*AccountData, error) {
+	resp, err := s.c.updateAccount(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+// UpdateWithRetry Fetches the account, applies the mutation and patches it, repeating the cycle on version conflicts
+// up to maxAttempts times.
+func (s *accountsService) UpdateWithRetry(ctx context.Context, id string, mutate AccountMutation, maxAttempts int) (*AccountData, error) {
+	resp, err := s.c.updateAccountWithRetry(ctx, id, mutate, maxAttempts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+// List Lists a page of the accounts matching the options, with the links to the other pages.
+func (s *accountsService) List(ctx context.Context, opts ListOptions) ([]AccountData, *Links, error) {
+	resp, err := s.c.listAccounts(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp.Data, resp.Links, nil
+}
+
+// Iterate Returns an iterator over every account matching the options. No request is made until Next is called.
+func (s *accountsService) Iterate(ctx context.Context, opts ListOptions) *AccountIterator {
+	return s.c.iterateAccounts(ctx, opts)
+}
@@ -0,0 +1,76 @@
+//go:build !integration
+// +build !integration
+
+package organisation_api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/CG-SS/organisation-api/fakeapi"
+)
+
+func TestAccountsService(t *testing.T) {
+	s := fakeapi.NewServer()
+	defer s.Close()
+
+	c, err := NewClient(WithBaseURL(s.RootURL().String()), WithHTTPClient(s.Client()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	accounts := c.Accounts()
+	ctx := context.Background()
+
+	created, err := accounts.Create(ctx, mockAccountData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.ID != mockAccountData.ID {
+		t.Fatal("Expected created account", mockAccountData.ID, "got", created.ID)
+	}
+
+	fetched, err := accounts.Get(ctx, mockAccountData.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fetched.Attributes.Bic = "NWBKGB22"
+	updated, err := accounts.Update(ctx, *fetched)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Attributes.Bic != "NWBKGB22" || *updated.Version != *fetched.Version+1 {
+		t.Fatal("Update wasn't applied! Got", updated.Attributes.Bic, *updated.Version)
+	}
+
+	retried, err := accounts.UpdateWithRetry(ctx, mockAccountData.ID, func(data *AccountData) error {
+		data.Attributes.Bic = "NWBKGB42"
+		return nil
+	}, 3)
+	if err != nil || retried.Attributes.Bic != "NWBKGB42" {
+		t.Fatal("Expected retried update, got", retried, err)
+	}
+
+	list, links, err := accounts.List(ctx, ListOptions{PageSize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || links == nil {
+		t.Fatal("Expected a single account with links, got", list, links)
+	}
+
+	it := accounts.Iterate(ctx, ListOptions{})
+	for it.Next() {
+	}
+	if it.Err() != nil {
+		t.Fatal(it.Err())
+	}
+
+	if err := accounts.Delete(ctx, mockAccountData.ID, *retried.Version); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := accounts.Get(ctx, mockAccountData.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatal("Expected not found after delete, got", err)
+	}
+}
@@ -0,0 +1,163 @@
+package organisation_api
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RequestSigner Signs the outgoing requests. It is called on every attempt, right before sending.
+type RequestSigner interface {
+	Sign(req *http.Request) error
+}
+
+// HTTPSigner Signs requests following the draft-cavage HTTP Signatures scheme used by Form3, with an RSA or ECDSA key.
+// The signature covers (request-target), host and date, plus digest and content-type for requests with a body.
+type HTTPSigner struct {
+	KeyID     string
+	Key       crypto.Signer
+	algorithm string
+	now       func() time.Time
+}
+
+// NewHTTPSigner Builds a signer for the given key ID and private key, which must be RSA or ECDSA.
+func NewHTTPSigner(keyID string, key crypto.Signer) (*HTTPSigner, error) {
+	if keyID == "" {
+		return nil, errors.New("organisation api: signer key ID is required")
+	}
+
+	var algorithm string
+	switch key.(type) {
+	case *rsa.PrivateKey:
+		algorithm = "rsa-sha256"
+	case *ecdsa.PrivateKey:
+		algorithm = "ecdsa-sha256"
+	default:
+		return nil, fmt.Errorf("organisation api: unsupported signing key %T", key)
+	}
+
+	return &HTTPSigner{
+		KeyID:     keyID,
+		Key:       key,
+		algorithm: algorithm,
+		now:       time.Now,
+	}, nil
+}
+
+// LoadPrivateKeyPEM Parses an RSA or ECDSA private key in PKCS #1, SEC 1 or PKCS #8 PEM format.
+func LoadPrivateKeyPEM(pemBytes []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("organisation api: no PEM block found")
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("organisation api: unsupported private key %T", key)
+		}
+		return signer, nil
+	}
+
+	return nil, fmt.Errorf("organisation api: unsupported PEM block %q", block.Type)
+}
+
+// Sign Sets the Date, Digest and Authorization headers of the request.
+func (s *HTTPSigner) Sign(req *http.Request) error {
+	req.Header.Set("Date", s.now().UTC().Format(http.TimeFormat))
+
+	headers := []string{"(request-target)", "host", "date"}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := readReplayableBody(req)
+		if err != nil {
+			return err
+		}
+
+		sum := sha256.Sum256(body)
+		req.Header.Set("Digest", "SHA-256="+base64.StdEncoding.EncodeToString(sum[:]))
+		headers = append(headers, "digest", "content-type")
+	}
+
+	signingString := buildSigningString(req, headers)
+	hashed := sha256.Sum256([]byte(signingString))
+
+	signature, err := s.Key.Sign(rand.Reader, hashed[:], crypto.SHA256)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf(`Signature keyId="%s",algorithm="%s",headers="%s",signature="%s"`,
+		s.KeyID, s.algorithm, strings.Join(headers, " "), base64.StdEncoding.EncodeToString(signature)))
+
+	return nil
+}
+
+// buildSigningString Builds the string to sign, one "name: value" line per covered header.
+func buildSigningString(req *http.Request, headers []string) string {
+	lines := make([]string, len(headers))
+	for i, h := range headers {
+		var value string
+		switch h {
+		case "(request-target)":
+			value = strings.ToLower(req.Method) + " " + req.URL.RequestURI()
+		case "host":
+			value = req.Host
+			if value == "" {
+				value = req.URL.Host
+			}
+		default:
+			value = req.Header.Get(h)
+		}
+
+		lines[i] = h + ": " + value
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// readReplayableBody Reads the body of the request, leaving a fresh copy in place for sending it.
+func readReplayableBody(req *http.Request) ([]byte, error) {
+	if req.GetBody == nil {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		closeBody(req.Body)
+
+		req.Body = io.NopCloser(strings.NewReader(string(body)))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(string(body))), nil
+		}
+		return body, nil
+	}
+
+	rc, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(rc)
+
+	return io.ReadAll(rc)
+}
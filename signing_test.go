@@ -0,0 +1,186 @@
+//go:build !integration
+// +build !integration
+
+package organisation_api
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/CG-SS/organisation-api/fakeapi"
+)
+
+func generatePEMKeys(t *testing.T) map[string][]byte {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ecBytes, err := x509.MarshalECPrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs8Bytes, err := x509.MarshalPKCS8PrivateKey(rsaKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return map[string][]byte{
+		"PKCS #1 RSA": pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}),
+		"PKCS #8 RSA": pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8Bytes}),
+		"SEC 1 ECDSA": pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecBytes}),
+	}
+}
+
+func TestHTTPSigner_SignAgainstFakeServer(t *testing.T) {
+	for name, pemBytes := range generatePEMKeys(t) {
+		t.Run(name, func(t *testing.T) {
+			key, err := LoadPrivateKeyPEM(pemBytes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			signer, err := NewHTTPSigner("key-1", key)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			s := fakeapi.NewServer(fakeapi.WithSignatureVerification("key-1", key.Public()))
+			defer s.Close()
+
+			c, err := NewClient(WithBaseURL(s.RootURL().String()), WithHTTPClient(s.Client()), WithSigner(signer))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := c.CreateAccount(mockAccountData); err != nil {
+				t.Fatal("Signed create was rejected:", err)
+			}
+			if _, err := c.FetchAccount(mockAccountData.ID); err != nil {
+				t.Fatal("Signed fetch was rejected:", err)
+			}
+
+			unsigned, err := NewClient(WithBaseURL(s.RootURL().String()), WithHTTPClient(s.Client()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := unsigned.FetchAccount(mockAccountData.ID); !errors.Is(err, ErrUnauthorized) {
+				t.Fatal("Expected unsigned request to be rejected, got", err)
+			}
+		})
+	}
+}
+
+func TestHTTPSigner_SignWithWrongKey(t *testing.T) {
+	serverKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := fakeapi.NewServer(fakeapi.WithSignatureVerification("key-1", serverKey.Public()))
+	defer s.Close()
+
+	signer, err := NewHTTPSigner("key-1", clientKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewClient(WithBaseURL(s.RootURL().String()), WithHTTPClient(s.Client()), WithSigner(signer))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.CreateAccount(mockAccountData); !errors.Is(err, ErrUnauthorized) {
+		t.Fatal("Expected signature with the wrong key to be rejected, got", err)
+	}
+}
+
+func TestNewHTTPSigner_invalidKeys(t *testing.T) {
+	testCases := []struct {
+		name  string
+		keyID string
+		key   crypto.Signer
+	}{
+		{"Rejects empty key ID", "", &ecdsa.PrivateKey{}},
+		{"Rejects unsupported key", "key-1", unsupportedSigner{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewHTTPSigner(tc.keyID, tc.key); err == nil {
+				t.Fatal("Expected error")
+			}
+		})
+	}
+
+	if _, err := LoadPrivateKeyPEM([]byte("not a pem")); err == nil {
+		t.Fatal("Expected PEM error")
+	}
+}
+
+type unsupportedSigner struct {
+	crypto.Signer
+}
+
+func TestClient_signsAfterRateLimiterWait(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := NewHTTPSigner("key-1", key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var signedAt time.Time
+	signer.now = func() time.Time {
+		signedAt = time.Now()
+		return signedAt
+	}
+
+	limiter, err := NewRateLimiter(100, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const pause = 300 * time.Millisecond
+	limiter.pauseUntil(time.Now().Add(pause))
+
+	c, err := NewClient(
+		WithBaseURL("http://localhost:8080/v1/organisation/"),
+		WithSigner(signer),
+		WithRateLimiter(limiter),
+		WithRetryPolicy(nil),
+		WithHTTPClient(&http.Client{
+			Transport: roundTripAux(func(r *http.Request) (*http.Response, error) {
+				if r.Header.Get("Date") != signedAt.UTC().Format(http.TimeFormat) {
+					t.Error("Expected the Date header of the signature, got", r.Header.Get("Date"))
+				}
+				if stale := time.Since(signedAt); stale >= pause {
+					t.Error("Expected the request to be signed after the rate limiter wait, signed", stale, "before sending")
+				}
+				return &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody}, nil
+			}),
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.DeleteAccount(mockAccountData.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+}
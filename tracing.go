@@ -0,0 +1,370 @@
+package organisation_api
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptrace"
+	"strings"
+	"sync"
+	"time"
+)
+
+const traceParentHeader = "traceparent"
+
+// Tracer Starts the spans of the client, modelled after OpenTelemetry so it can be bridged to it. The client opens a
+// span around every account operation, with child spans for the DNS, connect, TLS and first byte phases of each
+// request.
+type Tracer interface {
+	// Start Starts a span, child of the span of the context if any, returning the context to use within it.
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// Span Unit of work of a trace. Must be safe for concurrent use, as the transport reports phases from its own
+// goroutines.
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	// SetError Records the error and marks the span as failed.
+	SetError(err error)
+	End()
+	SpanContext() SpanContext
+}
+
+// Attribute Key/value pair annotating a span.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Attr Builds an Attribute.
+func Attr(key string, value interface{}) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// SpanContext Identifiers of a span, propagated to the API in the W3C traceparent header.
+type SpanContext struct {
+	TraceID [16]byte
+	SpanID  [8]byte
+	Sampled bool
+}
+
+// IsValid Reports whether both IDs are set.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != [16]byte{} && sc.SpanID != [8]byte{}
+}
+
+// TraceParent Formats the span context as a W3C traceparent header value.
+func (sc SpanContext) TraceParent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+
+	return "00-" + hex.EncodeToString(sc.TraceID[:]) + "-" + hex.EncodeToString(sc.SpanID[:]) + "-" + flags
+}
+
+// ParseTraceParent Parses a W3C traceparent header value, e.g. to continue a trace received by a server.
+func ParseTraceParent(value string) (SpanContext, error) {
+	sc := SpanContext{}
+
+	parts := strings.Split(value, "-")
+	if len(parts) != 4 || parts[0] != "00" || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return sc, fmt.Errorf("organisation api: malformed traceparent %q", value)
+	}
+
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil {
+		return sc, fmt.Errorf("organisation api: malformed traceparent %q", value)
+	}
+	if _, err := hex.Decode(sc.TraceID[:], []byte(parts[1])); err != nil {
+		return sc, fmt.Errorf("organisation api: malformed traceparent %q", value)
+	}
+	if _, err := hex.Decode(sc.SpanID[:], []byte(parts[2])); err != nil {
+		return sc, fmt.Errorf("organisation api: malformed traceparent %q", value)
+	}
+	if !sc.IsValid() {
+		return sc, fmt.Errorf("organisation api: invalid traceparent %q", value)
+	}
+	sc.Sampled = flags[0]&1 == 1
+
+	return sc, nil
+}
+
+type spanCtxKey struct{}
+
+// ContextWithSpan Returns a context carrying the span, making it the parent of the spans started from it.
+func ContextWithSpan(ctx context.Context, span Span) context.Context {
+	return context.WithValue(ctx, spanCtxKey{}, span)
+}
+
+// SpanFromContext Returns the span carried by the context, or nil.
+func SpanFromContext(ctx context.Context) Span {
+	span, _ := ctx.Value(spanCtxKey{}).(Span)
+	return span
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...Attribute) {}
+func (noopSpan) SetError(error)             {}
+func (noopSpan) End()                       {}
+func (noopSpan) SpanContext() SpanContext   { return SpanContext{} }
+
+// startSpan Starts the span of the operation when the client config has a tracer.
+func (c *OrganisationApiClient) startSpan(ctx context.Context, op operation) (context.Context, Span) {
+	if c.ClientConfig.Tracer == nil {
+		return ctx, noopSpan{}
+	}
+
+	attrs := []Attribute{Attr("operation", op.name)}
+	if op.accountID != "" {
+		attrs = append(attrs, Attr("account.id", op.accountID))
+	}
+
+	ctx, span := c.ClientConfig.Tracer.Start(ctx, "organisation_api."+op.name, attrs...)
+	return ContextWithSpan(ctx, span), span
+}
+
+// endSpan Ends the span of an operation, recording its error with the URLs redacted.
+func (c *OrganisationApiClient) endSpan(span Span, err error) {
+	finishSpan(span, c.redactor().redactError(err))
+}
+
+func finishSpan(span Span, err error) {
+	if err != nil {
+		span.SetError(err)
+	}
+	span.End()
+}
+
+// annotateSpan Adds the request and its outcome to the span of the request context.
+func (c *OrganisationApiClient) annotateSpan(req *http.Request, resp *http.Response) {
+	span := SpanFromContext(req.Context())
+	if c.ClientConfig.Tracer == nil || span == nil {
+		return
+	}
+
+	span.SetAttributes(Attr("http.method", req.Method), Attr("http.url", c.redactor().RedactURL(req.URL)))
+	if id := req.Header.Get(requestIDHeader); id != "" {
+		span.SetAttributes(Attr("http.request_id", id))
+	}
+	if resp != nil {
+		span.SetAttributes(Attr("http.status_code", resp.StatusCode))
+	}
+}
+
+// traceRequest Propagates the span of the request context in the traceparent header and reports the phases of the
+// round trip as child spans. The returned function ends the phases left open by a failed round trip.
+func (c *OrganisationApiClient) traceRequest(req *http.Request) (*http.Request, func(err error)) {
+	span := SpanFromContext(req.Context())
+	if c.ClientConfig.Tracer == nil || span == nil {
+		return req, func(error) {}
+	}
+
+	if sc := span.SpanContext(); sc.IsValid() {
+		req.Header.Set(traceParentHeader, sc.TraceParent())
+	}
+
+	phases := &phaseSpans{tracer: c.ClientConfig.Tracer, ctx: req.Context(), spans: map[string]Span{}}
+	trace := &httptrace.ClientTrace{
+		DNSStart: func(info httptrace.DNSStartInfo) {
+			phases.start("dns", "dns", Attr("net.host.name", info.Host))
+		},
+		DNSDone: func(info httptrace.DNSDoneInfo) {
+			phases.end("dns", info.Err)
+		},
+		ConnectStart: func(network, addr string) {
+			phases.start("connect "+addr, "connect", Attr("net.peer.address", addr))
+		},
+		ConnectDone: func(network, addr string, err error) {
+			phases.end("connect "+addr, err)
+		},
+		TLSHandshakeStart: func() {
+			phases.start("tls", "tls")
+		},
+		TLSHandshakeDone: func(state tls.ConnectionState, err error) {
+			phases.end("tls", err)
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			span.SetAttributes(Attr("net.conn.reused", info.Reused))
+		},
+		WroteRequest: func(info httptrace.WroteRequestInfo) {
+			if info.Err == nil {
+				phases.start("first_byte", "first_byte")
+			}
+		},
+		GotFirstResponseByte: func() {
+			phases.end("first_byte", nil)
+		},
+	}
+
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace)), func(err error) {
+		phases.endAll(c.redactor().redactError(err))
+	}
+}
+
+// phaseSpans Child spans of the round trip phases in progress, keyed so concurrent dials are told apart.
+type phaseSpans struct {
+	tracer Tracer
+	ctx    context.Context
+	mu     sync.Mutex
+	spans  map[string]Span
+}
+
+func (p *phaseSpans) start(key string, name string, attrs ...Attribute) {
+	_, span := p.tracer.Start(p.ctx, name, attrs...)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.spans[key] = span
+}
+
+func (p *phaseSpans) end(key string, err error) {
+	p.mu.Lock()
+	span := p.spans[key]
+	delete(p.spans, key)
+	p.mu.Unlock()
+
+	if span != nil {
+		finishSpan(span, err)
+	}
+}
+
+func (p *phaseSpans) endAll(err error) {
+	p.mu.Lock()
+	spans := p.spans
+	p.spans = map[string]Span{}
+	p.mu.Unlock()
+
+	if err == nil {
+		err = errors.New("organisation api: round trip ended before the phase completed")
+	}
+	for _, span := range spans {
+		finishSpan(span, err)
+	}
+}
+
+// SpanRecorder In-memory Tracer keeping the ended spans, meant for tests.
+type SpanRecorder struct {
+	mu    sync.Mutex
+	spans []RecordedSpan
+}
+
+// RecordedSpan Snapshot of a span ended by a SpanRecorder.
+type RecordedSpan struct {
+	Name       string
+	Context    SpanContext
+	Parent     SpanContext
+	Attributes map[string]interface{}
+	Err        error
+	Start      time.Time
+	End        time.Time
+}
+
+// NewSpanRecorder Builds an empty recorder.
+func NewSpanRecorder() *SpanRecorder {
+	return &SpanRecorder{}
+}
+
+// Start Starts a span, child of the span of the context if any.
+func (r *SpanRecorder) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	span := &recordingSpan{
+		recorder: r,
+		data: RecordedSpan{
+			Name:       name,
+			Attributes: map[string]interface{}{},
+			Start:      time.Now(),
+		},
+	}
+
+	if parent := SpanFromContext(ctx); parent != nil {
+		span.data.Parent = parent.SpanContext()
+	}
+	if span.data.Parent.IsValid() {
+		span.data.Context.TraceID = span.data.Parent.TraceID
+	} else {
+		randomBytes(span.data.Context.TraceID[:])
+	}
+	randomBytes(span.data.Context.SpanID[:])
+	span.data.Context.Sampled = true
+	span.SetAttributes(attrs...)
+
+	return ContextWithSpan(ctx, span), span
+}
+
+// Spans Returns the spans ended so far, in the order they ended.
+func (r *SpanRecorder) Spans() []RecordedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	spans := make([]RecordedSpan, len(r.spans))
+	copy(spans, r.spans)
+
+	return spans
+}
+
+// Reset Drops the recorded spans.
+func (r *SpanRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.spans = nil
+}
+
+type recordingSpan struct {
+	recorder *SpanRecorder
+	mu       sync.Mutex
+	data     RecordedSpan
+	ended    bool
+}
+
+func (s *recordingSpan) SetAttributes(attrs ...Attribute) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, a := range attrs {
+		s.data.Attributes[a.Key] = a.Value
+	}
+}
+
+func (s *recordingSpan) SetError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.Err = err
+}
+
+func (s *recordingSpan) End() {
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.data.End = time.Now()
+
+	data := s.data
+	data.Attributes = make(map[string]interface{}, len(s.data.Attributes))
+	for k, v := range s.data.Attributes {
+		data.Attributes[k] = v
+	}
+	s.mu.Unlock()
+
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	s.recorder.spans = append(s.recorder.spans, data)
+}
+
+func (s *recordingSpan) SpanContext() SpanContext {
+	return s.data.Context
+}
+
+func randomBytes(b []byte) {
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,125 @@
+//go:build !integration
+// +build !integration
+
+package organisation_api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func findSpan(spans []RecordedSpan, name string) *RecordedSpan {
+	for i := range spans {
+		if spans[i].Name == name {
+			return &spans[i]
+		}
+	}
+
+	return nil
+}
+
+func TestTracer_operationSpans(t *testing.T) {
+	var traceParent string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceParent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	recorder := NewSpanRecorder()
+	c, err := NewClient(WithBaseURL(ts.URL+"/v1/organisation/"), WithTracer(recorder), WithRetryPolicy(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, parent := recorder.Start(context.Background(), "reconciliation")
+	if _, err := c.FetchAccountWithContext(mockAccountData.ID, ctx); !errors.Is(err, ErrNotFound) {
+		t.Fatal("Expected not found, got", err)
+	}
+	parent.End()
+
+	spans := recorder.Spans()
+	op := findSpan(spans, "organisation_api.FetchAccount")
+	if op == nil {
+		t.Fatal("Missing operation span in", spans)
+	}
+	if !errors.Is(op.Err, ErrNotFound) || op.Attributes["http.status_code"] != http.StatusNotFound ||
+		op.Attributes["account.id"] != mockAccountData.ID || op.Attributes["http.method"] != http.MethodGet {
+		t.Fatal("Operation span wasn't annotated! Got", op)
+	}
+	if op.Parent != parent.SpanContext() || op.Context.TraceID != parent.SpanContext().TraceID {
+		t.Fatal("Operation span should be a child of the context span")
+	}
+
+	propagated, err := ParseTraceParent(traceParent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if propagated != op.Context {
+		t.Fatal("Expected traceparent of the operation span, got", traceParent)
+	}
+
+	for _, name := range []string{"connect", "first_byte"} {
+		phase := findSpan(spans, name)
+		if phase == nil || phase.Parent != op.Context || phase.Err != nil {
+			t.Fatal("Expected child span", name, "got", phase)
+		}
+	}
+}
+
+func TestTracer_disabled(t *testing.T) {
+	var traceParent string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceParent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient(WithBaseURL(ts.URL + "/v1/organisation/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, _ := NewSpanRecorder().Start(context.Background(), "caller")
+	if _, err := c.DeleteAccountWithContext(mockAccountData.ID, 0, ctx); err != nil {
+		t.Fatal(err)
+	}
+	if traceParent != "" {
+		t.Fatal("Client without tracer shouldn't propagate traces, got", traceParent)
+	}
+}
+
+func TestParseTraceParent(t *testing.T) {
+	testCases := []struct {
+		name       string
+		value      string
+		shouldFail bool
+		sampled    bool
+	}{
+		{"Parses sampled", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", false, true},
+		{"Parses not sampled", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", false, false},
+		{"Fails with unknown version", "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", true, false},
+		{"Fails with zero trace ID", "00-00000000000000000000000000000000-00f067aa0ba902b7-01", true, false},
+		{"Fails with short span ID", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902-01", true, false},
+		{"Fails with non hex", "00-4bf92f3577b34da6a3ce929d0e0e473z-00f067aa0ba902b7-01", true, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sc, err := ParseTraceParent(tc.value)
+			if (err != nil) != tc.shouldFail {
+				t.Fatal("Fail condition didn't match! Got", err, "should've been", tc.shouldFail)
+			}
+			if err != nil {
+				return
+			}
+
+			if sc.Sampled != tc.sampled || sc.TraceParent() != tc.value {
+				t.Fatal("Expected", tc.value, "got", sc.TraceParent())
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package organisation_api
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/CG-SS/organisation-api/iban"
+)
+
+var uuidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+const (
+	maxNames      = 4
+	maxAltNames   = 3
+	maxNameLength = 140
+)
+
+// FieldError Validation failure of a single field, identified by its JSON path.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationErrors List of field errors found while validating an AccountData. It matches ErrValidation.
+type ValidationErrors []FieldError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+
+	return "organisation api: invalid account: " + strings.Join(msgs, "; ")
+}
+
+// Is Matches ErrValidation.
+func (e ValidationErrors) Is(target error) bool {
+	return target == ErrValidation
+}
+
+type fieldRequirement int
+
+const (
+	optional fieldRequirement = iota
+	required
+	forbidden
+)
+
+// countryRule Form3 rules for the bank and account identifiers of a country.
+type countryRule struct {
+	bankID        fieldRequirement
+	bankIDFormat  *regexp.Regexp
+	bankIDCode    BankIDCode
+	bicRequired   bool
+	accountNumber *regexp.Regexp
+	ibanForbidden bool
+}
+
+var countryRules = map[Country]countryRule{
+	"AU": {optional, regexp.MustCompile(`^[0-9]{6}$`), "AUBSB", true, regexp.MustCompile(`^[1-9][0-9]{5,9}$`), true},
+	"BE": {required, regexp.MustCompile(`^[0-9]{3}$`), "BE", false, regexp.MustCompile(`^[0-9]{7}$`), false},
+	"CA": {optional, regexp.MustCompile(`^0[0-9]{8}$`), "CACPA", true, regexp.MustCompile(`^[0-9]{7,12}$`), true},
+	"CH": {required, regexp.MustCompile(`^[0-9]{5}$`), "CHBCC", false, regexp.MustCompile(`^[0-9]{12}$`), false},
+	"DE": {required, regexp.MustCompile(`^[0-9]{8}$`), "DEBLZ", false, regexp.MustCompile(`^[0-9]{7}$`), false},
+	"ES": {required, regexp.MustCompile(`^[0-9]{8}$`), "ESNCC", false, regexp.MustCompile(`^[0-9]{10}$`), false},
+	"FR": {required, regexp.MustCompile(`^[0-9]{10}$`), "FR", false, regexp.MustCompile(`^[0-9]{10}$`), false},
+	"GB": {required, regexp.MustCompile(`^[0-9]{6}$`), "GBDSC", true, regexp.MustCompile(`^[0-9]{8}$`), false},
+	"GR": {required, regexp.MustCompile(`^[0-9]{7}$`), "GRBIC", false, regexp.MustCompile(`^[0-9]{16}$`), false},
+	"HK": {optional, regexp.MustCompile(`^[0-9]{3}$`), "HKNCC", true, regexp.MustCompile(`^[0-9]{9,12}$`), true},
+	"IT": {required, regexp.MustCompile(`^[0-9]{10,11}$`), "ITNCC", false, regexp.MustCompile(`^[0-9]{12}$`), false},
+	"LU": {required, regexp.MustCompile(`^[0-9]{3}$`), "LULUX", false, regexp.MustCompile(`^[0-9]{13}$`), false},
+	"NL": {forbidden, nil, "", true, regexp.MustCompile(`^[0-9]{10}$`), false},
+	"PL": {required, regexp.MustCompile(`^[0-9]{8}$`), "PLKNR", false, regexp.MustCompile(`^[0-9]{16}$`), false},
+	"PT": {required, regexp.MustCompile(`^[0-9]{8}$`), "PTNCC", false, regexp.MustCompile(`^[0-9]{11}$`), false},
+	"US": {required, regexp.MustCompile(`^[0-9]{9}$`), "USABA", true, regexp.MustCompile(`^[0-9]{6,17}$`), true},
+}
+
+// Validate Checks the account against the Form3 rules, including the per country rules for bank_id, bank_id_code,
+// bic, account_number and iban, whose checksum is verified. Returns nil or ValidationErrors.
+func (d AccountData) Validate() error {
+	var errs ValidationErrors
+	add := func(field string, format string, args ...interface{}) {
+		errs = append(errs, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if !uuidRegex.MatchString(d.ID) {
+		add("id", "must be a UUID")
+	}
+	if !uuidRegex.MatchString(d.OrganisationID) {
+		add("organisation_id", "must be a UUID")
+	}
+	if d.Type != accountsPath {
+		add("type", "must be %q", accountsPath)
+	}
+	if d.Version != nil && *d.Version < 0 {
+		add("version", "must not be negative")
+	}
+
+	if d.Attributes == nil {
+		add("attributes", "is required")
+		return errs
+	}
+	a := d.Attributes
+
+	validateNames(a.Name, "attributes.name", 1, maxNames, add)
+	validateNames(a.AlternativeNames, "attributes.alternative_names", 0, maxAltNames, add)
+
+	if a.AccountClassification != nil && !a.AccountClassification.IsKnown() {
+		add("attributes.account_classification", "must be Personal or Business")
+	}
+	if a.Status != nil && !a.Status.IsKnown() {
+		add("attributes.status", "must be pending, confirmed, closed or failed")
+	}
+	if a.BaseCurrency != "" && !a.BaseCurrency.IsKnown() {
+		add("attributes.base_currency", "must be an ISO 4217 code")
+	}
+	if a.Bic != "" {
+		if _, err := iban.ParseBIC(a.Bic); err != nil {
+			add("attributes.bic", "%s", err.Error())
+		}
+	}
+
+	if a.Country == nil || *a.Country == "" {
+		add("attributes.country", "is required")
+		return errs
+	}
+	if !a.Country.IsKnown() {
+		add("attributes.country", "must be an ISO 3166-1 alpha-2 code")
+		return errs
+	}
+
+	rule, ok := countryRules[*a.Country]
+	if !ok {
+		if a.BankIDCode != "" && !a.BankIDCode.IsKnown() {
+			add("attributes.bank_id_code", "is not a known bank id code")
+		}
+		validateIban(a.Iban, string(*a.Country), add)
+		return errs.orNil()
+	}
+
+	switch {
+	case rule.bankID == required && a.BankID == "":
+		add("attributes.bank_id", "is required for %s", *a.Country)
+	case rule.bankID == forbidden && a.BankID != "":
+		add("attributes.bank_id", "is not supported for %s", *a.Country)
+	case a.BankID != "" && rule.bankIDFormat != nil && !rule.bankIDFormat.MatchString(a.BankID):
+		add("attributes.bank_id", "has an invalid format for %s", *a.Country)
+	}
+
+	if a.BankIDCode != rule.bankIDCode {
+		if rule.bankIDCode == "" {
+			add("attributes.bank_id_code", "is not supported for %s", *a.Country)
+		} else {
+			add("attributes.bank_id_code", "must be %s for %s", rule.bankIDCode, *a.Country)
+		}
+	}
+
+	if rule.bicRequired && a.Bic == "" {
+		add("attributes.bic", "is required for %s", *a.Country)
+	}
+	if a.AccountNumber != "" && !rule.accountNumber.MatchString(a.AccountNumber) {
+		add("attributes.account_number", "has an invalid format for %s", *a.Country)
+	}
+	if rule.ibanForbidden && a.Iban != "" {
+		add("attributes.iban", "is not supported for %s", *a.Country)
+	} else {
+		validateIban(a.Iban, string(*a.Country), add)
+	}
+
+	return errs.orNil()
+}
+
+func validateNames(names []string, field string, min int, max int, add func(string, string, ...interface{})) {
+	if len(names) < min || len(names) > max {
+		add(field, "must have between %d and %d entries", min, max)
+	}
+
+	for i, n := range names {
+		if strings.TrimSpace(n) == "" || len(n) > maxNameLength {
+			add(fmt.Sprintf("%s[%d]", field, i), "must have between 1 and %d characters", maxNameLength)
+		}
+	}
+}
+
+func validateIban(s string, country string, add func(string, string, ...interface{})) {
+	if s == "" {
+		return
+	}
+
+	err := iban.Validate(s)
+	if errors.Is(err, iban.ErrUnsupportedCountry) {
+		// the national structure is unknown, only the checksum can be verified
+		err = iban.ValidateChecksum(s)
+	}
+	if err != nil {
+		add("attributes.iban", "%s", err.Error())
+		return
+	}
+	if !strings.HasPrefix(strings.ToUpper(strings.TrimSpace(s)), country) {
+		add("attributes.iban", "must be an IBAN for %s", country)
+	}
+}
+
+// orNil Avoids returning a typed nil inside the error interface.
+func (e ValidationErrors) orNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+
+	return e
+}
@@ -0,0 +1,152 @@
+//go:build !integration
+// +build !integration
+
+package organisation_api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func withAttributes(mutate func(a *AccountAttributes)) AccountData {
+	d := mockAccountData
+	attributes := *mockAccountData.Attributes
+	mutate(&attributes)
+	d.Attributes = &attributes
+
+	return d
+}
+
+func TestAccountData_Validate(t *testing.T) {
+	testCases := []struct {
+		name           string
+		data           AccountData
+		expectedFields []string
+	}{
+		{"Accepts valid GB account", mockAccountData, nil},
+		{"Accepts valid DE account", withAttributes(func(a *AccountAttributes) {
+			a.Country = CountryDE.Ptr()
+			a.BankID = "37040044"
+			a.BankIDCode = BankIDCodeDEBLZ
+			a.AccountNumber = "0532013"
+			a.Bic = ""
+			a.Iban = ""
+		}), nil},
+		{"Rejects missing identifiers", AccountData{Attributes: mockAccountData.Attributes}, []string{"id", "organisation_id", "type"}},
+		{"Rejects missing attributes", AccountData{
+			ID:             mockAccountData.ID,
+			OrganisationID: mockAccountData.OrganisationID,
+			Type:           "accounts",
+		}, []string{"attributes"}},
+		{"Rejects missing country", withAttributes(func(a *AccountAttributes) {
+			a.Country = nil
+		}), []string{"attributes.country"}},
+		{"Rejects wrong GB identifiers", withAttributes(func(a *AccountAttributes) {
+			a.BankID = "4003"
+			a.BankIDCode = "DEBLZ"
+			a.AccountNumber = "1234"
+			a.Bic = ""
+		}), []string{"attributes.bank_id", "attributes.bank_id_code", "attributes.bic", "attributes.account_number"}},
+		{"Rejects bank_id for NL", withAttributes(func(a *AccountAttributes) {
+			a.Country = CountryNL.Ptr()
+			a.BankIDCode = ""
+			a.AccountNumber = "0417164300"
+			a.Iban = ""
+		}), []string{"attributes.bank_id"}},
+		{"Rejects iban for US", withAttributes(func(a *AccountAttributes) {
+			a.Country = CountryUS.Ptr()
+			a.BankID = "021000021"
+			a.BankIDCode = BankIDCodeUSABA
+			a.AccountNumber = "123456789"
+		}), []string{"attributes.iban"}},
+		{"Accepts BR iban", withAttributes(func(a *AccountAttributes) {
+			a.Country = Country("BR").Ptr()
+			a.Iban = "BR1800360305000010009795493C1"
+		}), nil},
+		{"Accepts iban of country without known structure", withAttributes(func(a *AccountAttributes) {
+			a.Country = Country("MU").Ptr()
+			a.Iban = "MU17BOMM0101101030300200000MUR"
+		}), nil},
+		{"Rejects iban checksum of country without known structure", withAttributes(func(a *AccountAttributes) {
+			a.Country = Country("MU").Ptr()
+			a.Iban = "MU17BOMM0101101030300200000MUX"
+		}), []string{"attributes.iban"}},
+		{"Rejects invalid iban checksum", withAttributes(func(a *AccountAttributes) {
+			a.Iban = "GB28NWBK40030212764204"
+		}), []string{"attributes.iban"}},
+		{"Rejects iban of another country", withAttributes(func(a *AccountAttributes) {
+			a.Iban = "DE89370400440532013000"
+		}), []string{"attributes.iban"}},
+		{"Rejects malformed values", withAttributes(func(a *AccountAttributes) {
+			a.AccountClassification = AccountClassification("Personnal").Ptr()
+			a.Bic = "NWBK"
+			a.BaseCurrency = "pounds"
+			a.Name = nil
+		}), []string{"attributes.name", "attributes.account_classification", "attributes.base_currency", "attributes.bic"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.data.Validate()
+			if len(tc.expectedFields) == 0 {
+				if err != nil {
+					t.Fatal("Expected valid account, got", err)
+				}
+				return
+			}
+
+			var validationErrs ValidationErrors
+			if !errors.As(err, &validationErrs) || !errors.Is(err, ErrValidation) {
+				t.Fatal("Expected validation errors, got", err)
+			}
+			if len(validationErrs) != len(tc.expectedFields) {
+				t.Fatal("Expected errors for", tc.expectedFields, "got", validationErrs)
+			}
+			for i, field := range tc.expectedFields {
+				if validationErrs[i].Field != field {
+					t.Fatal("Expected errors for", tc.expectedFields, "got", validationErrs)
+				}
+			}
+		})
+	}
+}
+
+func TestOrganisationApiClient_CreateAccountValidatesBeforeSending(t *testing.T) {
+	c := &OrganisationApiClient{
+		Client: &http.Client{},
+		ClientConfig: &ClientConfig{
+			RootUrl:              defaultRootUrl,
+			DebugLog:             nil,
+			IsDebugEnabled:       false,
+			ValidateBeforeCreate: true,
+		},
+	}
+
+	c.Client.Transport = roundTripAux(
+		func(r *http.Request) (*http.Response, error) {
+			t.Fatal("Invalid account was sent!")
+			return nil, nil
+		},
+	)
+
+	_, err := c.CreateAccount(AccountData{})
+	if !errors.Is(err, ErrValidation) {
+		t.Fatal("Expected validation error, got", err)
+	}
+}
+
+func TestAccountData_ValidateKeepsValuesOutOfMessages(t *testing.T) {
+	d := withAttributes(func(a *AccountAttributes) {
+		a.Iban = "GB71NWBK4003021276420X"
+	})
+
+	err := d.Validate()
+	if err == nil {
+		t.Fatal("Expected invalid iban error")
+	}
+	if strings.Contains(err.Error(), "1276420X") || strings.Contains(err.Error(), "NWBK4003") {
+		t.Fatal("Expected the iban to be left out of the error, got", err)
+	}
+}